@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/Lacsw/rntly/internal/auth"
 	"github.com/Lacsw/rntly/internal/database"
 	"github.com/Lacsw/rntly/internal/handler"
 	"github.com/Lacsw/rntly/internal/middleware"
+	"github.com/Lacsw/rntly/internal/scheduler"
 	"github.com/Lacsw/rntly/internal/service"
 	"github.com/Lacsw/rntly/internal/store"
 )
@@ -17,26 +23,139 @@ func main() {
 	defer db.Close()
 
 	// Initialize stores
+	txManager := store.NewTxManager(db)
 	propertyStore := store.NewPropertyStore(db)
+	buildingStore := store.NewBuildingStore(db)
 	tenantStore := store.NewTenantStore(db)
 	leaseStore := store.NewLeaseStore(db)
+	paymentStore := store.NewPaymentStore(db)
+	invoiceStore := store.NewInvoiceStore(db)
+	chargeStore := store.NewChargeStore(db)
+	lateFeePolicyStore := store.NewLateFeePolicyStore(db)
+	depositStore := store.NewDepositStore(db)
+	leasePartyStore := store.NewLeasePartyStore(db)
+	guarantorStore := store.NewGuarantorStore(db)
+	applicationStore := store.NewApplicationStore(db)
+	searchStore := store.NewSearchStore(db)
+	userStore := store.NewUserStore(db)
+	sessionStore := store.NewSessionStore(db)
+	organizationStore := store.NewOrganizationStore(db)
+	documentStore := store.NewDocumentStore(db)
+	maintenanceStore := store.NewMaintenanceStore(db)
+	ownerStore := store.NewOwnerStore(db)
+	expenseStore := store.NewExpenseStore(db)
+	vendorStore := store.NewVendorStore(db)
 
 	// Initialize services
-	propertyService := service.NewPropertyService(propertyStore)
+	clock := service.SystemClock{}
+	propertyService := service.NewPropertyService(propertyStore, buildingStore, ownerStore)
+	buildingService := service.NewBuildingService(buildingStore, propertyStore, ownerStore)
 	tenantService := service.NewTenantService(tenantStore)
-	leaseService := service.NewLeaseService(leaseStore, propertyStore, tenantStore)
+	paymentService := service.NewPaymentService(paymentStore, chargeStore, leaseStore)
+	depositService := service.NewDepositService(depositStore, leaseStore, paymentService, txManager, depositReturnDays())
+	leaseService := service.NewLeaseService(leaseStore, propertyStore, tenantStore, chargeStore, invoiceStore, depositService, txManager, clock)
+	leasePartyService := service.NewLeasePartyService(leasePartyStore, leaseStore, tenantStore, guarantorStore, txManager)
+	guarantorService := service.NewGuarantorService(guarantorStore, leaseStore)
+	searchService := service.NewSearchService(searchStore)
+	userService := service.NewUserService(userStore, tenantStore)
+	organizationService := service.NewOrganizationService(organizationStore, userService, txManager)
+	authService := service.NewAuthService(userStore, sessionStore, sessionTTL(), clock)
+	applicationService := service.NewApplicationService(applicationStore, propertyStore, tenantService, leaseService, txManager, clock)
+	invoiceService := service.NewInvoiceService(invoiceStore, leaseStore)
+	lateFeeService := service.NewLateFeeService(lateFeePolicyStore, chargeStore, invoiceStore, leaseStore, propertyStore)
+	documentService := service.NewDocumentService(documentStore, leaseStore)
+	maintenanceService := service.NewMaintenanceService(maintenanceStore, propertyStore, tenantStore, vendorStore, expenseStore)
+	ownerService := service.NewOwnerService(ownerStore, propertyStore, userStore, txManager)
+	expenseService := service.NewExpenseService(expenseStore, propertyStore)
+	vendorService := service.NewVendorService(vendorStore, propertyStore, maintenanceStore)
+	portalService := service.NewPortalService(leaseService, tenantService, paymentService, invoiceService, maintenanceService, documentStore)
 
 	// Initialize handlers
 	propertyHandler := handler.NewPropertyHandler(propertyService)
+	buildingHandler := handler.NewBuildingHandler(buildingService)
 	tenantHandler := handler.NewTenantHandler(tenantService)
-	leaseHandler := handler.NewLeaseHandler(leaseService)
+	leaseHandler := handler.NewLeaseHandler(leaseService, leasePartyService, guarantorService)
+	leasePartyHandler := handler.NewLeasePartyHandler(leasePartyService)
+	guarantorHandler := handler.NewGuarantorHandler(guarantorService)
+	applicationHandler := handler.NewApplicationHandler(applicationService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	authHandler := handler.NewAuthHandler(authService)
+	userHandler := handler.NewUserHandler(userService)
+	organizationHandler := handler.NewOrganizationHandler(organizationService)
+	paymentHandler := handler.NewPaymentHandler(paymentService)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceService)
+	lateFeeHandler := handler.NewLateFeeHandler(lateFeeService)
+	depositHandler := handler.NewDepositHandler(depositService)
+	documentHandler := handler.NewDocumentHandler(documentService)
+	portalHandler := handler.NewPortalHandler(portalService)
+	ownerHandler := handler.NewOwnerHandler(ownerService)
+	expenseHandler := handler.NewExpenseHandler(expenseService)
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceService)
+	vendorHandler := handler.NewVendorHandler(vendorService)
 
-	// Setup router
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Create the first account on a fresh install
+	if err := userService.EnsureAdmin(ctx, os.Getenv("ADMIN_EMAIL"), os.Getenv("ADMIN_PASSWORD")); err != nil {
+		log.Fatal("Failed to create initial user: ", err)
+	}
+
+	// Start background jobs
+	jobs := scheduler.New()
+	jobs.Add("advance-lease-statuses", time.Hour, leaseService.AdvanceStatuses)
+	jobs.Add("generate-invoices", time.Hour, func(ctx context.Context) error {
+		return invoiceService.GenerateDue(ctx, clock.Now())
+	})
+	jobs.Add("apply-late-fees", time.Hour, func(ctx context.Context) error {
+		return lateFeeService.ApplyLateFees(ctx, clock.Now())
+	})
+	jobs.Add("purge-expired-sessions", time.Hour, authService.PurgeExpiredSessions)
+	jobs.Start(ctx)
+
+	// Setup router. Properties, tenants, leases, owners and users check permissions
+	// in their services; the back-office routes require PermOperations, and
+	// their services check PermOperationsDelete before removing anything.
 	mux := http.NewServeMux()
+	ops := func(h http.HandlerFunc) http.HandlerFunc {
+		return middleware.Require(auth.PermOperations, h)
+	}
 
 	// Health
 	mux.HandleFunc("GET /health", handler.Health)
 
+	// Auth
+	mux.HandleFunc("POST /auth/login", authHandler.Login)
+	mux.HandleFunc("POST /auth/logout", authHandler.Logout)
+	mux.HandleFunc("GET /auth/me", authHandler.Me)
+
+	// Users
+	mux.HandleFunc("GET /users", userHandler.List)
+	mux.HandleFunc("GET /users/{id}", userHandler.Get)
+	mux.HandleFunc("POST /users", userHandler.Create)
+	mux.HandleFunc("PUT /users/{id}", userHandler.Update)
+	mux.HandleFunc("DELETE /users/{id}", userHandler.Delete)
+
+	// Organizations
+	mux.HandleFunc("GET /organizations/current", organizationHandler.Current)
+	mux.HandleFunc("GET /organizations", organizationHandler.List)
+	mux.HandleFunc("POST /organizations", organizationHandler.Create)
+
+	// Tenant portal
+	mux.HandleFunc("GET /me", portalHandler.Profile)
+	mux.HandleFunc("GET /me/lease", portalHandler.Lease)
+	mux.HandleFunc("GET /me/leases", portalHandler.Leases)
+	mux.HandleFunc("GET /me/payments", portalHandler.Payments)
+	mux.HandleFunc("GET /me/invoices", portalHandler.Invoices)
+	mux.HandleFunc("GET /me/ledger", portalHandler.Ledger)
+	mux.HandleFunc("GET /me/documents", portalHandler.Documents)
+	mux.HandleFunc("GET /me/requests", portalHandler.Requests)
+	mux.HandleFunc("GET /me/requests/{id}", portalHandler.Request)
+	mux.HandleFunc("POST /me/requests", portalHandler.SubmitRequest)
+
+	// Search
+	mux.HandleFunc("GET /search", ops(searchHandler.Search))
+
 	// Properties
 	mux.HandleFunc("GET /properties", propertyHandler.List)
 	mux.HandleFunc("GET /properties/{id}", propertyHandler.Get)
@@ -44,6 +163,28 @@ func main() {
 	mux.HandleFunc("PUT /properties/{id}", propertyHandler.Update)
 	mux.HandleFunc("DELETE /properties/{id}", propertyHandler.Delete)
 
+	// Owners
+	mux.HandleFunc("GET /owners", ownerHandler.List)
+	mux.HandleFunc("GET /owners/{id}", ownerHandler.Get)
+	mux.HandleFunc("POST /owners", ownerHandler.Create)
+	mux.HandleFunc("PUT /owners/{id}", ownerHandler.Update)
+	mux.HandleFunc("DELETE /owners/{id}", ownerHandler.Delete)
+	mux.HandleFunc("GET /owners/{id}/properties", ownerHandler.Properties)
+	mux.HandleFunc("GET /owners/{id}/statements/{month}", ownerHandler.Statement)
+
+	// Expenses
+	mux.HandleFunc("GET /properties/{id}/expenses", ops(expenseHandler.GetByProperty))
+	mux.HandleFunc("POST /properties/{id}/expenses", ops(expenseHandler.Create))
+	mux.HandleFunc("DELETE /properties/{id}/expenses/{expenseId}", ops(expenseHandler.Delete))
+
+	// Buildings
+	mux.HandleFunc("GET /buildings", ops(buildingHandler.List))
+	mux.HandleFunc("GET /buildings/{id}", ops(buildingHandler.Get))
+	mux.HandleFunc("POST /buildings", ops(buildingHandler.Create))
+	mux.HandleFunc("PUT /buildings/{id}", ops(buildingHandler.Update))
+	mux.HandleFunc("DELETE /buildings/{id}", ops(buildingHandler.Delete))
+	mux.HandleFunc("GET /buildings/{id}/units", ops(buildingHandler.Units))
+
 	// Tenants
 	mux.HandleFunc("GET /tenants", tenantHandler.List)
 	mux.HandleFunc("GET /tenants/{id}", tenantHandler.Get)
@@ -57,13 +198,126 @@ func main() {
 	mux.HandleFunc("POST /leases", leaseHandler.Create)
 	mux.HandleFunc("PUT /leases/{id}", leaseHandler.Update)
 	mux.HandleFunc("DELETE /leases/{id}", leaseHandler.Delete)
+	mux.HandleFunc("POST /leases/{id}/renew", leaseHandler.Renew)
+	mux.HandleFunc("POST /leases/{id}/terminate", leaseHandler.Terminate)
 	mux.HandleFunc("GET /properties/{propertyId}/leases", leaseHandler.GetByProperty)
 	mux.HandleFunc("GET /tenants/{tenantId}/leases", leaseHandler.GetByTenant)
 
+	// Lease parties
+	mux.HandleFunc("GET /leases/{id}/parties", ops(leasePartyHandler.List))
+	mux.HandleFunc("POST /leases/{id}/parties", ops(leasePartyHandler.Add))
+	mux.HandleFunc("PUT /leases/{id}/parties/{tenantId}", ops(leasePartyHandler.Update))
+	mux.HandleFunc("DELETE /leases/{id}/parties/{tenantId}", ops(leasePartyHandler.Remove))
+
+	// Guarantors
+	mux.HandleFunc("GET /guarantors", ops(guarantorHandler.List))
+	mux.HandleFunc("GET /guarantors/{id}", ops(guarantorHandler.Get))
+	mux.HandleFunc("POST /guarantors", ops(guarantorHandler.Create))
+	mux.HandleFunc("PUT /guarantors/{id}", ops(guarantorHandler.Update))
+	mux.HandleFunc("DELETE /guarantors/{id}", ops(guarantorHandler.Delete))
+	mux.HandleFunc("GET /leases/{id}/guarantors", ops(guarantorHandler.GetByLease))
+	mux.HandleFunc("POST /leases/{id}/guarantors", ops(guarantorHandler.Link))
+	mux.HandleFunc("DELETE /leases/{id}/guarantors/{guarantorId}", ops(guarantorHandler.Unlink))
+
+	// Applications
+	mux.HandleFunc("GET /applications", ops(applicationHandler.List))
+	mux.HandleFunc("GET /applications/{id}", ops(applicationHandler.Get))
+	mux.HandleFunc("POST /applications", ops(applicationHandler.Create))
+	mux.HandleFunc("PUT /applications/{id}", ops(applicationHandler.Update))
+	mux.HandleFunc("DELETE /applications/{id}", ops(applicationHandler.Delete))
+	mux.HandleFunc("POST /applications/{id}/review", ops(applicationHandler.Review))
+	mux.HandleFunc("POST /applications/{id}/approve", ops(applicationHandler.Approve))
+	mux.HandleFunc("POST /applications/{id}/reject", ops(applicationHandler.Reject))
+	mux.HandleFunc("POST /applications/{id}/withdraw", ops(applicationHandler.Withdraw))
+
+	// Payments
+	mux.HandleFunc("GET /leases/{id}/payments", ops(paymentHandler.GetByLease))
+	mux.HandleFunc("POST /leases/{id}/payments", ops(paymentHandler.Create))
+	mux.HandleFunc("GET /leases/{id}/ledger", ops(paymentHandler.Ledger))
+	mux.HandleFunc("DELETE /payments/{id}", ops(paymentHandler.Delete))
+
+	// Invoices
+	mux.HandleFunc("GET /invoices", ops(invoiceHandler.List))
+	mux.HandleFunc("GET /leases/{id}/invoices", ops(invoiceHandler.GetByLease))
+
+	// Late fees
+	mux.HandleFunc("GET /properties/{id}/late-fee-policy", ops(lateFeeHandler.GetPropertyPolicy))
+	mux.HandleFunc("PUT /properties/{id}/late-fee-policy", ops(lateFeeHandler.SetPropertyPolicy))
+	mux.HandleFunc("DELETE /properties/{id}/late-fee-policy", ops(lateFeeHandler.DeletePropertyPolicy))
+	mux.HandleFunc("GET /leases/{id}/late-fee-policy", ops(lateFeeHandler.GetLeasePolicy))
+	mux.HandleFunc("PUT /leases/{id}/late-fee-policy", ops(lateFeeHandler.SetLeasePolicy))
+	mux.HandleFunc("DELETE /leases/{id}/late-fee-policy", ops(lateFeeHandler.DeleteLeasePolicy))
+
+	// Documents
+	mux.HandleFunc("GET /leases/{id}/documents", ops(documentHandler.GetByLease))
+	mux.HandleFunc("POST /leases/{id}/documents", ops(documentHandler.Create))
+	mux.HandleFunc("DELETE /leases/{id}/documents/{documentId}", ops(documentHandler.Delete))
+
+	// Maintenance
+	mux.HandleFunc("GET /maintenance", ops(maintenanceHandler.List))
+	mux.HandleFunc("GET /maintenance/{id}", ops(maintenanceHandler.Get))
+	mux.HandleFunc("PUT /maintenance/{id}", ops(maintenanceHandler.Update))
+	mux.HandleFunc("DELETE /maintenance/{id}", ops(maintenanceHandler.Delete))
+	mux.HandleFunc("GET /properties/{id}/maintenance", ops(maintenanceHandler.GetByProperty))
+	mux.HandleFunc("POST /properties/{id}/maintenance", ops(maintenanceHandler.Create))
+	mux.HandleFunc("POST /maintenance/{id}/assign", ops(maintenanceHandler.Assign))
+	mux.HandleFunc("POST /maintenance/{id}/start", ops(maintenanceHandler.Start))
+	mux.HandleFunc("POST /maintenance/{id}/resolve", ops(maintenanceHandler.Resolve))
+	mux.HandleFunc("POST /maintenance/{id}/close", ops(maintenanceHandler.Close))
+	mux.HandleFunc("POST /maintenance/{id}/reopen", ops(maintenanceHandler.Reopen))
+	mux.HandleFunc("GET /maintenance/{id}/comments", ops(maintenanceHandler.Comments))
+	mux.HandleFunc("POST /maintenance/{id}/comments", ops(maintenanceHandler.AddComment))
+	mux.HandleFunc("GET /maintenance/{id}/costs", ops(maintenanceHandler.Costs))
+	mux.HandleFunc("POST /maintenance/{id}/costs", ops(maintenanceHandler.AddCost))
+	mux.HandleFunc("DELETE /maintenance/{id}/costs/{costId}", ops(maintenanceHandler.DeleteCost))
+
+	// Vendors
+	mux.HandleFunc("GET /vendors", ops(vendorHandler.List))
+	mux.HandleFunc("GET /vendors/{id}", ops(vendorHandler.Get))
+	mux.HandleFunc("POST /vendors", ops(vendorHandler.Create))
+	mux.HandleFunc("PUT /vendors/{id}", ops(vendorHandler.Update))
+	mux.HandleFunc("DELETE /vendors/{id}", ops(vendorHandler.Delete))
+	mux.HandleFunc("GET /vendors/{id}/jobs", ops(vendorHandler.Jobs))
+	mux.HandleFunc("POST /vendors/{id}/jobs", ops(vendorHandler.RecordJob))
+	mux.HandleFunc("DELETE /vendors/{id}/jobs/{jobId}", ops(vendorHandler.DeleteJob))
+	mux.HandleFunc("GET /vendors/{id}/invoices", ops(vendorHandler.Invoices))
+	mux.HandleFunc("POST /vendors/{id}/invoices", ops(vendorHandler.AddInvoice))
+	mux.HandleFunc("POST /vendors/{id}/invoices/{invoiceId}/pay", ops(vendorHandler.PayInvoice))
+	mux.HandleFunc("DELETE /vendors/{id}/invoices/{invoiceId}", ops(vendorHandler.DeleteInvoice))
+	mux.HandleFunc("GET /properties/{id}/vendors", ops(vendorHandler.GetByProperty))
+	mux.HandleFunc("GET /properties/{id}/jobs", ops(vendorHandler.PropertyJobs))
+
+	// Deposits
+	mux.HandleFunc("GET /deposits", ops(depositHandler.List))
+	mux.HandleFunc("GET /leases/{id}/deposit", ops(depositHandler.Get))
+	mux.HandleFunc("POST /leases/{id}/deposit/deductions", ops(depositHandler.AddDeduction))
+	mux.HandleFunc("DELETE /leases/{id}/deposit/deductions/{deductionId}", ops(depositHandler.DeleteDeduction))
+	mux.HandleFunc("POST /leases/{id}/deposit/settle", ops(depositHandler.Settle))
+
 	port := ":8080"
 	log.Printf("🏠 rntly API starting on http://localhost%s", port)
 
-	if err := http.ListenAndServe(port, middleware.CORS(mux)); err != nil {
+	if err := http.ListenAndServe(port, middleware.CORS(middleware.Auth(authService)(mux))); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// sessionTTL reads how long a login stays valid from SESSION_TTL_HOURS,
+// defaulting to one week.
+func sessionTTL() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("SESSION_TTL_HOURS"))
+	if err != nil || hours <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// depositReturnDays reads the statutory deposit return window from
+// DEPOSIT_RETURN_DAYS, defaulting to 30 days.
+func depositReturnDays() int {
+	days, err := strconv.Atoi(os.Getenv("DEPOSIT_RETURN_DAYS"))
+	if err != nil || days <= 0 {
+		return 30
+	}
+	return days
+}
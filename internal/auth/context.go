@@ -0,0 +1,33 @@
+// Package auth carries the signed-in user through a request's context.
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the signed-in user.
+func WithUser(ctx context.Context, user model.User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the signed-in user carried by ctx, if any.
+func UserFrom(ctx context.Context) (model.User, bool) {
+	user, ok := ctx.Value(userKey{}).(model.User)
+	return user, ok
+}
+
+// BearerToken returns the token of an "Authorization: Bearer" header, or an
+// empty string if the request has none.
+func BearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+// Roles a user can have. Staff run the business; agents handle leasing but
+// cannot remove records; owners and tenants only see what concerns them,
+// owners including their own owner record and statements.
+const (
+	RoleStaff  = "staff"
+	RoleAgent  = "agent"
+	RoleOwner  = "owner"
+	RoleTenant = "tenant"
+)
+
+// Permission is an action a role may be allowed to perform.
+type Permission string
+
+const (
+	PermPropertiesRead   Permission = "properties:read"
+	PermPropertiesWrite  Permission = "properties:write"
+	PermPropertiesDelete Permission = "properties:delete"
+	PermTenantsRead      Permission = "tenants:read"
+	PermTenantsWrite     Permission = "tenants:write"
+	PermTenantsDelete    Permission = "tenants:delete"
+	PermLeasesRead       Permission = "leases:read"
+	PermLeasesWrite      Permission = "leases:write"
+	PermLeasesDelete     Permission = "leases:delete"
+	PermOwnersRead       Permission = "owners:read"
+	PermOwnersWrite      Permission = "owners:write"
+	PermOwnersDelete     Permission = "owners:delete"
+	PermUsersManage      Permission = "users:manage"
+
+	// PermOrganizationsManage lets staff of the default organization
+	// provision the others; staff elsewhere hold it to no effect.
+	PermOrganizationsManage Permission = "organizations:manage"
+
+	// PermPortal lets a tenant use the self-service /me endpoints.
+	PermPortal Permission = "portal"
+
+	// PermOperations covers the back-office endpoints that have no finer
+	// permissions of their own: payments, invoices, deposits, applications
+	// and the like.
+	PermOperations Permission = "operations"
+
+	// PermOperationsDelete lets a user remove back-office records, which
+	// PermOperations alone does not.
+	PermOperationsDelete Permission = "operations:delete"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleStaff: {
+		PermPropertiesRead, PermPropertiesWrite, PermPropertiesDelete,
+		PermTenantsRead, PermTenantsWrite, PermTenantsDelete,
+		PermLeasesRead, PermLeasesWrite, PermLeasesDelete,
+		PermOwnersRead, PermOwnersWrite, PermOwnersDelete,
+		PermUsersManage, PermOrganizationsManage, PermOperations, PermOperationsDelete,
+	},
+	RoleAgent: {
+		PermPropertiesRead,
+		PermTenantsRead, PermTenantsWrite,
+		PermLeasesRead, PermLeasesWrite,
+		PermOwnersRead,
+		PermOperations,
+	},
+	RoleOwner: {
+		PermPropertiesRead, PermTenantsRead, PermLeasesRead,
+		PermOwnersRead,
+	},
+	RoleTenant: {
+		PermPropertiesRead, PermTenantsRead, PermLeasesRead,
+		PermPortal,
+	},
+}
+
+// IsValidRole reports whether role is one of the known roles.
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Can reports whether the role grants the permission.
+func Can(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed reports whether the signed-in user carried by ctx may perform the
+// action. A context without a user is never allowed.
+func Allowed(ctx context.Context, perm Permission) bool {
+	user, ok := UserFrom(ctx)
+	return ok && Can(user.Role, perm)
+}
+
+// Scope describes which records the user carried by a context may see.
+// Every user is confined to their organization; within it staff and agents
+// see everything, owners their properties and tenants their own leases.
+// Background work runs without a user and sees all organizations.
+type Scope struct {
+	OrganizationID string
+	Role           string
+	UserID         string
+	TenantID       string
+}
+
+// ScopeFrom returns the visibility scope of the user carried by ctx.
+func ScopeFrom(ctx context.Context) Scope {
+	user, ok := UserFrom(ctx)
+	if !ok {
+		return Scope{}
+	}
+	return Scope{
+		OrganizationID: user.OrganizationID,
+		Role:           user.Role,
+		UserID:         user.ID,
+		TenantID:       tenantID(user),
+	}
+}
+
+func tenantID(user model.User) string {
+	if user.TenantID == nil {
+		return ""
+	}
+	return *user.TenantID
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type ApplicationHandler struct {
+	service *service.ApplicationService
+}
+
+func NewApplicationHandler(s *service.ApplicationService) *ApplicationHandler {
+	return &ApplicationHandler{service: s}
+}
+
+type applicationInput struct {
+	PropertyID       string  `json:"property_id"`
+	FirstName        string  `json:"first_name"`
+	LastName         string  `json:"last_name"`
+	Email            string  `json:"email"`
+	Phone            string  `json:"phone"`
+	Employer         string  `json:"employer"`
+	JobTitle         string  `json:"job_title"`
+	MonthlyIncome    float64 `json:"monthly_income"`
+	DesiredStartDate string  `json:"desired_start_date"`
+	DesiredEndDate   string  `json:"desired_end_date"`
+}
+
+// details parses the dates of the input, writing a 400 response and
+// returning false if either is malformed.
+func (in applicationInput) details(w http.ResponseWriter) (service.ApplicantDetails, bool) {
+	startDate, err := time.Parse("2006-01-02", in.DesiredStartDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid desired_start_date format, use YYYY-MM-DD")
+		return service.ApplicantDetails{}, false
+	}
+
+	endDate, err := time.Parse("2006-01-02", in.DesiredEndDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid desired_end_date format, use YYYY-MM-DD")
+		return service.ApplicantDetails{}, false
+	}
+
+	return service.ApplicantDetails{
+		FirstName:        in.FirstName,
+		LastName:         in.LastName,
+		Email:            in.Email,
+		Phone:            in.Phone,
+		Employer:         in.Employer,
+		JobTitle:         in.JobTitle,
+		MonthlyIncome:    in.MonthlyIncome,
+		DesiredStartDate: startDate,
+		DesiredEndDate:   endDate,
+	}, true
+}
+
+func (h *ApplicationHandler) List(w http.ResponseWriter, r *http.Request) {
+	applications, err := h.service.List(r.Context(), r.URL.Query().Get("status"))
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch applications")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, applications)
+}
+
+func (h *ApplicationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	application, err := h.service.GetByID(r.Context(), id)
+	if errors.Is(err, service.ErrApplicationNotFound) {
+		response.Error(w, http.StatusNotFound, "application not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch application")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, application)
+}
+
+func (h *ApplicationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input applicationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	details, ok := input.details(w)
+	if !ok {
+		return
+	}
+
+	application, err := h.service.Submit(r.Context(), input.PropertyID, details)
+	if !h.writeError(w, err, "failed to submit application") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, application)
+}
+
+func (h *ApplicationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input applicationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	details, ok := input.details(w)
+	if !ok {
+		return
+	}
+
+	application, err := h.service.Update(r.Context(), id, details)
+	if !h.writeError(w, err, "failed to update application") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, application)
+}
+
+func (h *ApplicationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := h.service.Delete(r.Context(), id)
+	if !h.writeError(w, err, "failed to delete application") {
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *ApplicationHandler) Review(w http.ResponseWriter, r *http.Request) {
+	application, err := h.service.Review(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to update application") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, application)
+}
+
+func (h *ApplicationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input struct {
+		RentAmount *float64 `json:"rent_amount"`
+		Deposit    float64  `json:"deposit"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	application, err := h.service.Approve(r.Context(), id, input.RentAmount, input.Deposit)
+	if !h.writeError(w, err, "failed to approve application") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, application)
+}
+
+func (h *ApplicationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input struct {
+		Reason string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	application, err := h.service.Reject(r.Context(), id, input.Reason)
+	if !h.writeError(w, err, "failed to reject application") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, application)
+}
+
+func (h *ApplicationHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input struct {
+		Reason string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	application, err := h.service.Withdraw(r.Context(), id, input.Reason)
+	if !h.writeError(w, err, "failed to withdraw application") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, application)
+}
+
+// writeError maps an application service error to a response. It returns
+// true if err is nil and the caller should carry on.
+func (h *ApplicationHandler) writeError(w http.ResponseWriter, err error, fallback string) bool {
+	var conflict *service.LeaseConflictError
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrApplicationNotFound):
+		response.Error(w, http.StatusNotFound, "application not found")
+	case errors.Is(err, service.ErrInvalidTransition):
+		response.Error(w, http.StatusConflict, "application cannot move to that status")
+	case errors.Is(err, service.ErrPropertyUnavailable):
+		response.Error(w, http.StatusConflict, "property is not available for the requested dates")
+	case errors.As(err, &conflict):
+		writeLeaseConflict(w, conflict)
+	case errors.Is(err, service.ErrInvalidDateRange):
+		response.Error(w, http.StatusBadRequest, "end date must be after start date")
+	case errors.Is(err, service.ErrInvalidInput):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+	default:
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+	return false
+}
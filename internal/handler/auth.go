@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func NewAuthHandler(s *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: s}
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	session, err := h.service.Login(r.Context(), input.Email, input.Password)
+	if errors.Is(err, service.ErrInvalidCredentials) {
+		response.Error(w, http.StatusUnauthorized, "invalid email or password")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to log in")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, session)
+}
+
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Logout(r.Context(), auth.BearerToken(r)); err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to log out")
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	user, _ := auth.UserFrom(r.Context())
+	response.JSON(w, http.StatusOK, user)
+}
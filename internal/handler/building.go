@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type BuildingHandler struct {
+	service *service.BuildingService
+}
+
+func NewBuildingHandler(s *service.BuildingService) *BuildingHandler {
+	return &BuildingHandler{service: s}
+}
+
+type buildingInput struct {
+	Name      string        `json:"name"`
+	Address   model.Address `json:"address"`
+	OwnerID   *string       `json:"owner_id"`
+	Amenities []string      `json:"amenities"`
+}
+
+func (h *BuildingHandler) List(w http.ResponseWriter, r *http.Request) {
+	buildings, err := h.service.List(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch buildings")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, buildings)
+}
+
+func (h *BuildingHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	building, err := h.service.GetByID(r.Context(), id)
+	if errors.Is(err, service.ErrBuildingNotFound) {
+		response.Error(w, http.StatusNotFound, "building not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch building")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, building)
+}
+
+func (h *BuildingHandler) Units(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	asOf, err := dateQuery(r, "as_of", time.Now().UTC())
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid as_of format, use YYYY-MM-DD")
+		return
+	}
+
+	units, err := h.service.Units(r.Context(), id, asOf)
+	if errors.Is(err, service.ErrBuildingNotFound) {
+		response.Error(w, http.StatusNotFound, "building not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch units")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, units)
+}
+
+func (h *BuildingHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input buildingInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	building, err := h.service.Create(r.Context(), input.Name, input.Address, input.OwnerID, input.Amenities)
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to create building")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, building)
+}
+
+func (h *BuildingHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input buildingInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	building, err := h.service.Update(r.Context(), id, input.Name, input.Address, input.OwnerID, input.Amenities)
+	if errors.Is(err, service.ErrBuildingNotFound) {
+		response.Error(w, http.StatusNotFound, "building not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to update building")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, building)
+}
+
+func (h *BuildingHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := h.service.Delete(r.Context(), id)
+	if errors.Is(err, service.ErrBuildingNotFound) {
+		response.Error(w, http.StatusNotFound, "building not found")
+		return
+	}
+	if errors.Is(err, service.ErrBuildingNotEmpty) {
+		response.Error(w, http.StatusConflict, "building still has units")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete building")
+		return
+	}
+
+	response.NoContent(w)
+}
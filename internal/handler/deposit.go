@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type DepositHandler struct {
+	service *service.DepositService
+}
+
+func NewDepositHandler(s *service.DepositService) *DepositHandler {
+	return &DepositHandler{service: s}
+}
+
+func (h *DepositHandler) List(w http.ResponseWriter, r *http.Request) {
+	settlements, err := h.service.List(r.Context(), r.URL.Query().Get("status"))
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch deposit settlements")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, settlements)
+}
+
+func (h *DepositHandler) Get(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	settlement, err := h.service.GetByLeaseID(r.Context(), leaseID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrDepositNotFound) {
+		response.Error(w, http.StatusNotFound, "deposit settlement not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch deposit settlement")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, settlement)
+}
+
+func (h *DepositHandler) AddDeduction(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	var input struct {
+		Category    string  `json:"category"`
+		Description string  `json:"description"`
+		Amount      float64 `json:"amount"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	deduction, err := h.service.AddDeduction(r.Context(), leaseID, input.Category, input.Description, input.Amount)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrDepositNotFound) {
+		response.Error(w, http.StatusNotFound, "deposit settlement not found")
+		return
+	}
+	if errors.Is(err, service.ErrDepositSettled) {
+		response.Error(w, http.StatusConflict, "deposit is already settled")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to add deduction")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, deduction)
+}
+
+func (h *DepositHandler) DeleteDeduction(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+	deductionID := r.PathValue("deductionId")
+
+	err := h.service.DeleteDeduction(r.Context(), leaseID, deductionID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrDepositNotFound) {
+		response.Error(w, http.StatusNotFound, "deposit settlement not found")
+		return
+	}
+	if errors.Is(err, service.ErrDeductionNotFound) {
+		response.Error(w, http.StatusNotFound, "deduction not found")
+		return
+	}
+	if errors.Is(err, service.ErrDepositSettled) {
+		response.Error(w, http.StatusConflict, "deposit is already settled")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete deduction")
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *DepositHandler) Settle(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	var input struct {
+		SettledAt string `json:"settled_at"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	settledAt, err := time.Parse("2006-01-02", input.SettledAt)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid settled_at format, use YYYY-MM-DD")
+		return
+	}
+
+	settlement, err := h.service.Settle(r.Context(), leaseID, settledAt)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrDepositNotFound) {
+		response.Error(w, http.StatusNotFound, "deposit settlement not found")
+		return
+	}
+	if errors.Is(err, service.ErrDepositSettled) {
+		response.Error(w, http.StatusConflict, "deposit is already settled")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to settle deposit")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, settlement)
+}
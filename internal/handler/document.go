@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type DocumentHandler struct {
+	service *service.DocumentService
+}
+
+func NewDocumentHandler(s *service.DocumentService) *DocumentHandler {
+	return &DocumentHandler{service: s}
+}
+
+func (h *DocumentHandler) GetByLease(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	documents, err := h.service.GetByLeaseID(r.Context(), leaseID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch documents")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, documents)
+}
+
+func (h *DocumentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	var input struct {
+		Title    string `json:"title"`
+		Category string `json:"category"`
+		URL      string `json:"url"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	document, err := h.service.Create(r.Context(), leaseID, input.Title, input.Category, input.URL)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to add document")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, document)
+}
+
+func (h *DocumentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+	id := r.PathValue("documentId")
+
+	err := h.service.Delete(r.Context(), leaseID, id)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrDocumentNotFound) {
+		response.Error(w, http.StatusNotFound, "document not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete document")
+		return
+	}
+
+	response.NoContent(w)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type ExpenseHandler struct {
+	service *service.ExpenseService
+}
+
+func NewExpenseHandler(s *service.ExpenseService) *ExpenseHandler {
+	return &ExpenseHandler{service: s}
+}
+
+func (h *ExpenseHandler) GetByProperty(w http.ResponseWriter, r *http.Request) {
+	propertyID := r.PathValue("id")
+
+	expenses, err := h.service.GetByPropertyID(r.Context(), propertyID)
+	if errors.Is(err, service.ErrPropertyNotFound) {
+		response.Error(w, http.StatusNotFound, "property not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch expenses")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, expenses)
+}
+
+func (h *ExpenseHandler) Create(w http.ResponseWriter, r *http.Request) {
+	propertyID := r.PathValue("id")
+
+	var input struct {
+		IncurredOn  string  `json:"incurred_on"`
+		Category    string  `json:"category"`
+		Description string  `json:"description"`
+		Amount      float64 `json:"amount"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	incurredOn, err := time.Parse("2006-01-02", input.IncurredOn)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid incurred_on format, use YYYY-MM-DD")
+		return
+	}
+
+	expense, err := h.service.Create(r.Context(), propertyID, incurredOn, input.Category, input.Description, input.Amount)
+	if errors.Is(err, service.ErrPropertyNotFound) {
+		response.Error(w, http.StatusNotFound, "property not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to add expense")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, expense)
+}
+
+func (h *ExpenseHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	propertyID := r.PathValue("id")
+	id := r.PathValue("expenseId")
+
+	err := h.service.Delete(r.Context(), propertyID, id)
+	if errors.Is(err, service.ErrPropertyNotFound) {
+		response.Error(w, http.StatusNotFound, "property not found")
+		return
+	}
+	if errors.Is(err, service.ErrExpenseNotFound) {
+		response.Error(w, http.StatusNotFound, "expense not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete expense")
+		return
+	}
+
+	response.NoContent(w)
+}
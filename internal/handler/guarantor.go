@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type GuarantorHandler struct {
+	service *service.GuarantorService
+}
+
+func NewGuarantorHandler(s *service.GuarantorService) *GuarantorHandler {
+	return &GuarantorHandler{service: s}
+}
+
+type guarantorInput struct {
+	FirstName    string   `json:"first_name"`
+	LastName     string   `json:"last_name"`
+	Email        string   `json:"email"`
+	Phone        string   `json:"phone"`
+	Address      string   `json:"address"`
+	AnnualIncome *float64 `json:"annual_income"`
+	LiabilityCap *float64 `json:"liability_cap"`
+}
+
+func (h *GuarantorHandler) List(w http.ResponseWriter, r *http.Request) {
+	guarantors, err := h.service.List(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch guarantors")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, guarantors)
+}
+
+func (h *GuarantorHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	guarantor, err := h.service.GetByID(r.Context(), id)
+	if errors.Is(err, service.ErrGuarantorNotFound) {
+		response.Error(w, http.StatusNotFound, "guarantor not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch guarantor")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, guarantor)
+}
+
+func (h *GuarantorHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input guarantorInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	guarantor, err := h.service.Create(r.Context(), input.FirstName, input.LastName, input.Email, input.Phone, input.Address, input.AnnualIncome, input.LiabilityCap)
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to create guarantor")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, guarantor)
+}
+
+func (h *GuarantorHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input guarantorInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	guarantor, err := h.service.Update(r.Context(), id, input.FirstName, input.LastName, input.Email, input.Phone, input.Address, input.AnnualIncome, input.LiabilityCap)
+	if errors.Is(err, service.ErrGuarantorNotFound) {
+		response.Error(w, http.StatusNotFound, "guarantor not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to update guarantor")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, guarantor)
+}
+
+func (h *GuarantorHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := h.service.Delete(r.Context(), id)
+	if errors.Is(err, service.ErrGuarantorNotFound) {
+		response.Error(w, http.StatusNotFound, "guarantor not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete guarantor")
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *GuarantorHandler) GetByLease(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	guarantors, err := h.service.GetByLeaseID(r.Context(), leaseID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch guarantors")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, guarantors)
+}
+
+func (h *GuarantorHandler) Link(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	var input struct {
+		GuarantorID string `json:"guarantor_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	err := h.service.Link(r.Context(), leaseID, input.GuarantorID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrGuarantorAlreadyLinked) {
+		response.Error(w, http.StatusConflict, "guarantor is already linked to this lease")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to link guarantor")
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *GuarantorHandler) Unlink(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+	guarantorID := r.PathValue("guarantorId")
+
+	err := h.service.Unlink(r.Context(), leaseID, guarantorID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrGuarantorNotFound) {
+		response.Error(w, http.StatusNotFound, "guarantor not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to unlink guarantor")
+		return
+	}
+
+	response.NoContent(w)
+}
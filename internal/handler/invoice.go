@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type InvoiceHandler struct {
+	service *service.InvoiceService
+}
+
+func NewInvoiceHandler(s *service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{service: s}
+}
+
+func (h *InvoiceHandler) List(w http.ResponseWriter, r *http.Request) {
+	invoices, err := h.service.List(r.Context(), r.URL.Query().Get("status"))
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch invoices")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invoices)
+}
+
+func (h *InvoiceHandler) GetByLease(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	invoices, err := h.service.GetByLeaseID(r.Context(), leaseID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch invoices")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invoices)
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type LateFeeHandler struct {
+	service *service.LateFeeService
+}
+
+func NewLateFeeHandler(s *service.LateFeeService) *LateFeeHandler {
+	return &LateFeeHandler{service: s}
+}
+
+type lateFeePolicyInput struct {
+	GraceDays int      `json:"grace_days"`
+	FlatFee   float64  `json:"flat_fee"`
+	Percent   float64  `json:"percent"`
+	DailyFee  float64  `json:"daily_fee"`
+	MaxFee    *float64 `json:"max_fee"`
+}
+
+func (h *LateFeeHandler) GetPropertyPolicy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	policy, err := h.service.GetPropertyPolicy(r.Context(), id)
+	if errors.Is(err, service.ErrPropertyNotFound) {
+		response.Error(w, http.StatusNotFound, "property not found")
+		return
+	}
+	if errors.Is(err, service.ErrLateFeePolicyNotFound) {
+		response.Error(w, http.StatusNotFound, "late fee policy not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch late fee policy")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, policy)
+}
+
+func (h *LateFeeHandler) SetPropertyPolicy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input lateFeePolicyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	policy, err := h.service.SetPropertyPolicy(r.Context(), id, input.GraceDays, input.FlatFee, input.Percent, input.DailyFee, input.MaxFee)
+	if errors.Is(err, service.ErrPropertyNotFound) {
+		response.Error(w, http.StatusNotFound, "property not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to save late fee policy")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, policy)
+}
+
+func (h *LateFeeHandler) DeletePropertyPolicy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := h.service.DeletePropertyPolicy(r.Context(), id)
+	if errors.Is(err, service.ErrPropertyNotFound) {
+		response.Error(w, http.StatusNotFound, "property not found")
+		return
+	}
+	if errors.Is(err, service.ErrLateFeePolicyNotFound) {
+		response.Error(w, http.StatusNotFound, "late fee policy not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete late fee policy")
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *LateFeeHandler) GetLeasePolicy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	policy, err := h.service.GetLeasePolicy(r.Context(), id)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrLateFeePolicyNotFound) {
+		response.Error(w, http.StatusNotFound, "late fee policy not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch late fee policy")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, policy)
+}
+
+func (h *LateFeeHandler) SetLeasePolicy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input lateFeePolicyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	policy, err := h.service.SetLeasePolicy(r.Context(), id, input.GraceDays, input.FlatFee, input.Percent, input.DailyFee, input.MaxFee)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to save late fee policy")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, policy)
+}
+
+func (h *LateFeeHandler) DeleteLeasePolicy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := h.service.DeleteLeasePolicy(r.Context(), id)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrLateFeePolicyNotFound) {
+		response.Error(w, http.StatusNotFound, "late fee policy not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete late fee policy")
+		return
+	}
+
+	response.NoContent(w)
+}
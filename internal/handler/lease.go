@@ -6,20 +6,78 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Lacsw/rntly/internal/model"
 	"github.com/Lacsw/rntly/internal/response"
 	"github.com/Lacsw/rntly/internal/service"
 )
 
 type LeaseHandler struct {
-	service *service.LeaseService
+	service    *service.LeaseService
+	parties    *service.LeasePartyService
+	guarantors *service.GuarantorService
 }
 
-func NewLeaseHandler(s *service.LeaseService) *LeaseHandler {
-	return &LeaseHandler{service: s}
+func NewLeaseHandler(s *service.LeaseService, parties *service.LeasePartyService, guarantors *service.GuarantorService) *LeaseHandler {
+	return &LeaseHandler{
+		service:    s,
+		parties:    parties,
+		guarantors: guarantors,
+	}
+}
+
+// leaseFilter reads the listing filters from the query string.
+func leaseFilter(r *http.Request) (model.LeaseFilter, error) {
+	q := r.URL.Query()
+	filter := model.LeaseFilter{
+		Status:     q.Get("status"),
+		PropertyID: q.Get("property_id"),
+		TenantID:   q.Get("tenant_id"),
+	}
+
+	var err error
+	if filter.MinRent, err = floatQuery(r, "min_rent"); err != nil {
+		return filter, errors.New("invalid min_rent")
+	}
+	if filter.MaxRent, err = floatQuery(r, "max_rent"); err != nil {
+		return filter, errors.New("invalid max_rent")
+	}
+	if filter.StartFrom, err = optionalDateQuery(r, "start_from"); err != nil {
+		return filter, errors.New("invalid start_from format, use YYYY-MM-DD")
+	}
+	if filter.StartTo, err = optionalDateQuery(r, "start_to"); err != nil {
+		return filter, errors.New("invalid start_to format, use YYYY-MM-DD")
+	}
+	if filter.EndFrom, err = optionalDateQuery(r, "end_from"); err != nil {
+		return filter, errors.New("invalid end_from format, use YYYY-MM-DD")
+	}
+	if filter.EndTo, err = optionalDateQuery(r, "end_to"); err != nil {
+		return filter, errors.New("invalid end_to format, use YYYY-MM-DD")
+	}
+	return filter, nil
 }
 
 func (h *LeaseHandler) List(w http.ResponseWriter, r *http.Request) {
-	leases, err := h.service.List(r.Context())
+	filter, err := leaseFilter(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := pageRequest(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	leases, err := h.service.List(r.Context(), filter, page)
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch leases")
 		return
@@ -36,18 +94,42 @@ func (h *LeaseHandler) Get(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusNotFound, "lease not found")
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch lease")
 		return
 	}
 
-	response.JSON(w, http.StatusOK, lease)
+	parties, err := h.parties.GetByLeaseID(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch lease")
+		return
+	}
+
+	guarantors, err := h.guarantors.GetByLeaseID(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch lease")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, model.LeaseDetail{
+		Lease:      lease,
+		Parties:    parties,
+		Guarantors: guarantors,
+	})
 }
 
 func (h *LeaseHandler) GetByProperty(w http.ResponseWriter, r *http.Request) {
 	propertyID := r.PathValue("propertyId")
 
 	leases, err := h.service.GetByPropertyID(r.Context(), propertyID)
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch leases")
 		return
@@ -60,6 +142,10 @@ func (h *LeaseHandler) GetByTenant(w http.ResponseWriter, r *http.Request) {
 	tenantID := r.PathValue("tenantId")
 
 	leases, err := h.service.GetByTenantID(r.Context(), tenantID)
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch leases")
 		return
@@ -96,8 +182,9 @@ func (h *LeaseHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	lease, err := h.service.Create(r.Context(), input.PropertyID, input.TenantID, startDate, endDate, input.RentAmount, input.Deposit)
-	if errors.Is(err, service.ErrPropertyNotVacant) {
-		response.Error(w, http.StatusConflict, "property is not vacant")
+	var conflict *service.LeaseConflictError
+	if errors.As(err, &conflict) {
+		writeLeaseConflict(w, conflict)
 		return
 	}
 	if errors.Is(err, service.ErrInvalidDateRange) {
@@ -108,6 +195,10 @@ func (h *LeaseHandler) Create(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to create lease")
 		return
@@ -145,10 +236,15 @@ func (h *LeaseHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	lease, err := h.service.Update(r.Context(), id, startDate, endDate, input.RentAmount, input.Deposit, input.Status)
+	var conflict *service.LeaseConflictError
 	if errors.Is(err, service.ErrLeaseNotFound) {
 		response.Error(w, http.StatusNotFound, "lease not found")
 		return
 	}
+	if errors.As(err, &conflict) {
+		writeLeaseConflict(w, conflict)
+		return
+	}
 	if errors.Is(err, service.ErrInvalidDateRange) {
 		response.Error(w, http.StatusBadRequest, "end date must be after start date")
 		return
@@ -157,6 +253,10 @@ func (h *LeaseHandler) Update(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to update lease")
 		return
@@ -165,6 +265,133 @@ func (h *LeaseHandler) Update(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, lease)
 }
 
+func (h *LeaseHandler) Renew(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input struct {
+		EndDate         string  `json:"end_date"`
+		EscalationType  string  `json:"escalation_type"`
+		EscalationValue float64 `json:"escalation_value"`
+		BaseIndex       float64 `json:"base_index"`
+		CurrentIndex    float64 `json:"current_index"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	var endDate *time.Time
+	if input.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", input.EndDate)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid end_date format, use YYYY-MM-DD")
+			return
+		}
+		endDate = &parsed
+	}
+
+	escalation := service.Escalation{
+		Type:         input.EscalationType,
+		Value:        input.EscalationValue,
+		BaseIndex:    input.BaseIndex,
+		CurrentIndex: input.CurrentIndex,
+	}
+
+	lease, err := h.service.Renew(r.Context(), id, endDate, escalation)
+	var conflict *service.LeaseConflictError
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrLeaseAlreadyRenewed) {
+		response.Error(w, http.StatusConflict, "lease has already been renewed")
+		return
+	}
+	if errors.Is(err, service.ErrLeaseTerminated) {
+		response.Error(w, http.StatusConflict, "lease has been terminated")
+		return
+	}
+	if errors.As(err, &conflict) {
+		writeLeaseConflict(w, conflict)
+		return
+	}
+	if errors.Is(err, service.ErrInvalidDateRange) {
+		response.Error(w, http.StatusBadRequest, "end date must be after start date")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to renew lease")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, lease)
+}
+
+func (h *LeaseHandler) Terminate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input struct {
+		NoticeDate  string  `json:"notice_date"`
+		MoveOutDate string  `json:"move_out_date"`
+		Reason      string  `json:"reason"`
+		Fee         float64 `json:"fee"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	noticeDate, err := time.Parse("2006-01-02", input.NoticeDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid notice_date format, use YYYY-MM-DD")
+		return
+	}
+
+	moveOutDate, err := time.Parse("2006-01-02", input.MoveOutDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid move_out_date format, use YYYY-MM-DD")
+		return
+	}
+
+	lease, err := h.service.Terminate(r.Context(), id, noticeDate, moveOutDate, input.Reason, input.Fee)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrLeaseTerminated) {
+		response.Error(w, http.StatusConflict, "lease has already been terminated")
+		return
+	}
+	if errors.Is(err, service.ErrLeaseHasRenewal) {
+		response.Error(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to terminate lease")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, lease)
+}
+
 func (h *LeaseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
@@ -173,6 +400,10 @@ func (h *LeaseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusNotFound, "lease not found")
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to delete lease")
 		return
@@ -180,3 +411,10 @@ func (h *LeaseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	response.NoContent(w)
 }
+
+func writeLeaseConflict(w http.ResponseWriter, conflict *service.LeaseConflictError) {
+	response.JSON(w, http.StatusConflict, map[string]string{
+		"error":                "lease dates overlap an existing lease",
+		"conflicting_lease_id": conflict.LeaseID,
+	})
+}
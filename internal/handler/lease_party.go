@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type LeasePartyHandler struct {
+	service *service.LeasePartyService
+}
+
+func NewLeasePartyHandler(s *service.LeasePartyService) *LeasePartyHandler {
+	return &LeasePartyHandler{service: s}
+}
+
+func (h *LeasePartyHandler) List(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	parties, err := h.service.GetByLeaseID(r.Context(), leaseID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch lease parties")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, parties)
+}
+
+func (h *LeasePartyHandler) Add(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	var input struct {
+		TenantID string `json:"tenant_id"`
+		Role     string `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	party, err := h.service.Add(r.Context(), leaseID, input.TenantID, input.Role)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrLeasePartyExists) {
+		response.Error(w, http.StatusConflict, "tenant is already a party to this lease")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to add lease party")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, party)
+}
+
+func (h *LeasePartyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+	tenantID := r.PathValue("tenantId")
+
+	var input struct {
+		Role string `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	party, err := h.service.UpdateRole(r.Context(), leaseID, tenantID, input.Role)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrLeasePartyNotFound) {
+		response.Error(w, http.StatusNotFound, "lease party not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to update lease party")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, party)
+}
+
+func (h *LeasePartyHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+	tenantID := r.PathValue("tenantId")
+
+	err := h.service.Remove(r.Context(), leaseID, tenantID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrLeasePartyNotFound) {
+		response.Error(w, http.StatusNotFound, "lease party not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to remove lease party")
+		return
+	}
+
+	response.NoContent(w)
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type MaintenanceHandler struct {
+	service *service.MaintenanceService
+}
+
+func NewMaintenanceHandler(s *service.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{service: s}
+}
+
+type maintenanceInput struct {
+	TenantID    *string `json:"tenant_id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Priority    string  `json:"priority"`
+	Category    string  `json:"category"`
+}
+
+func (in maintenanceInput) details() service.MaintenanceDetails {
+	return service.MaintenanceDetails{
+		TenantID:    in.TenantID,
+		Title:       in.Title,
+		Description: in.Description,
+		Priority:    in.Priority,
+		Category:    in.Category,
+	}
+}
+
+// maintenanceFilter reads the listing filters from the query string.
+func maintenanceFilter(r *http.Request) model.MaintenanceFilter {
+	q := r.URL.Query()
+	return model.MaintenanceFilter{
+		PropertyID: q.Get("property_id"),
+		VendorID:   q.Get("vendor_id"),
+		Status:     q.Get("status"),
+		Priority:   q.Get("priority"),
+		Category:   q.Get("category"),
+	}
+}
+
+func (h *MaintenanceHandler) List(w http.ResponseWriter, r *http.Request) {
+	requests, err := h.service.List(r.Context(), maintenanceFilter(r))
+	if !h.writeError(w, err, "failed to fetch maintenance requests") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, requests)
+}
+
+func (h *MaintenanceHandler) GetByProperty(w http.ResponseWriter, r *http.Request) {
+	requests, err := h.service.GetByPropertyID(r.Context(), r.PathValue("id"), maintenanceFilter(r))
+	if !h.writeError(w, err, "failed to fetch maintenance requests") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, requests)
+}
+
+func (h *MaintenanceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	request, err := h.service.GetByID(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch maintenance request") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, request)
+}
+
+func (h *MaintenanceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input maintenanceInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	request, err := h.service.Create(r.Context(), r.PathValue("id"), input.details())
+	if !h.writeError(w, err, "failed to create maintenance request") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, request)
+}
+
+func (h *MaintenanceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var input maintenanceInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	request, err := h.service.Update(r.Context(), r.PathValue("id"), input.details())
+	if !h.writeError(w, err, "failed to update maintenance request") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, request)
+}
+
+func (h *MaintenanceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	err := h.service.Delete(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to delete maintenance request") {
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *MaintenanceHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		VendorID string `json:"vendor_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	request, err := h.service.Assign(r.Context(), r.PathValue("id"), input.VendorID)
+	if !h.writeError(w, err, "failed to assign maintenance request") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, request)
+}
+
+func (h *MaintenanceHandler) Start(w http.ResponseWriter, r *http.Request) {
+	request, err := h.service.Start(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to start maintenance request") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, request)
+}
+
+func (h *MaintenanceHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	request, err := h.service.Resolve(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to resolve maintenance request") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, request)
+}
+
+func (h *MaintenanceHandler) Close(w http.ResponseWriter, r *http.Request) {
+	request, err := h.service.Close(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to close maintenance request") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, request)
+}
+
+func (h *MaintenanceHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	request, err := h.service.Reopen(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to reopen maintenance request") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, request)
+}
+
+func (h *MaintenanceHandler) Comments(w http.ResponseWriter, r *http.Request) {
+	comments, err := h.service.Comments(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch comments") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, comments)
+}
+
+func (h *MaintenanceHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Body string `json:"body"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	comment, err := h.service.AddComment(r.Context(), r.PathValue("id"), input.Body)
+	if !h.writeError(w, err, "failed to add comment") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, comment)
+}
+
+func (h *MaintenanceHandler) Costs(w http.ResponseWriter, r *http.Request) {
+	costs, err := h.service.Costs(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch costs") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, costs)
+}
+
+func (h *MaintenanceHandler) AddCost(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		IncurredOn  string  `json:"incurred_on"`
+		Description string  `json:"description"`
+		Amount      float64 `json:"amount"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	incurredOn, err := time.Parse("2006-01-02", input.IncurredOn)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid incurred_on format, use YYYY-MM-DD")
+		return
+	}
+
+	cost, err := h.service.AddCost(r.Context(), r.PathValue("id"), incurredOn, input.Description, input.Amount)
+	if !h.writeError(w, err, "failed to add cost") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, cost)
+}
+
+func (h *MaintenanceHandler) DeleteCost(w http.ResponseWriter, r *http.Request) {
+	err := h.service.DeleteCost(r.Context(), r.PathValue("id"), r.PathValue("costId"))
+	if !h.writeError(w, err, "failed to delete cost") {
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// writeError maps a maintenance service error to a response. It returns
+// true if err is nil and the caller should carry on.
+func (h *MaintenanceHandler) writeError(w http.ResponseWriter, err error, fallback string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrMaintenanceRequestNotFound):
+		response.Error(w, http.StatusNotFound, "maintenance request not found")
+	case errors.Is(err, service.ErrPropertyNotFound):
+		response.Error(w, http.StatusNotFound, "property not found")
+	case errors.Is(err, service.ErrExpenseNotFound):
+		response.Error(w, http.StatusNotFound, "cost not found")
+	case errors.Is(err, service.ErrMaintenanceTransition), errors.Is(err, service.ErrMaintenanceRequestClosed),
+		errors.Is(err, service.ErrVendorInsuranceLapsed):
+		response.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidInput):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+	default:
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type OrganizationHandler struct {
+	service *service.OrganizationService
+}
+
+func NewOrganizationHandler(s *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{service: s}
+}
+
+// Current returns the organization the signed-in user belongs to.
+func (h *OrganizationHandler) Current(w http.ResponseWriter, r *http.Request) {
+	organization, err := h.service.Current(r.Context())
+	if errors.Is(err, service.ErrOrganizationNotFound) {
+		response.Error(w, http.StatusNotFound, "organization not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch organization")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, organization)
+}
+
+func (h *OrganizationHandler) List(w http.ResponseWriter, r *http.Request) {
+	organizations, err := h.service.List(r.Context())
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch organizations")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, organizations)
+}
+
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name  string `json:"name"`
+		Admin struct {
+			Email    string `json:"email"`
+			Name     string `json:"name"`
+			Password string `json:"password"`
+		} `json:"admin"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	organization, err := h.service.Create(r.Context(), input.Name, input.Admin.Email, input.Admin.Name, input.Admin.Password)
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrEmailTaken) {
+		response.Error(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to create organization")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, organization)
+}
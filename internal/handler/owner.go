@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type OwnerHandler struct {
+	service *service.OwnerService
+}
+
+func NewOwnerHandler(s *service.OwnerService) *OwnerHandler {
+	return &OwnerHandler{service: s}
+}
+
+type ownerInput struct {
+	UserID               *string       `json:"user_id"`
+	Name                 string        `json:"name"`
+	Email                string        `json:"email"`
+	Phone                string        `json:"phone"`
+	Address              model.Address `json:"address"`
+	ManagementFeePercent float64       `json:"management_fee_percent"`
+}
+
+func (in ownerInput) details() service.OwnerDetails {
+	return service.OwnerDetails{
+		UserID:               in.UserID,
+		Name:                 in.Name,
+		Email:                in.Email,
+		Phone:                in.Phone,
+		Address:              in.Address,
+		ManagementFeePercent: in.ManagementFeePercent,
+	}
+}
+
+func (h *OwnerHandler) List(w http.ResponseWriter, r *http.Request) {
+	owners, err := h.service.List(r.Context())
+	if !h.writeError(w, err, "failed to fetch owners") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, owners)
+}
+
+func (h *OwnerHandler) Get(w http.ResponseWriter, r *http.Request) {
+	owner, err := h.service.GetByID(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch owner") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, owner)
+}
+
+func (h *OwnerHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input ownerInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	owner, err := h.service.Create(r.Context(), input.details())
+	if !h.writeError(w, err, "failed to create owner") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, owner)
+}
+
+func (h *OwnerHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var input ownerInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	owner, err := h.service.Update(r.Context(), r.PathValue("id"), input.details())
+	if !h.writeError(w, err, "failed to update owner") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, owner)
+}
+
+func (h *OwnerHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	err := h.service.Delete(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to delete owner") {
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *OwnerHandler) Properties(w http.ResponseWriter, r *http.Request) {
+	asOf, err := dateQuery(r, "as_of", time.Now().UTC())
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid as_of format, use YYYY-MM-DD")
+		return
+	}
+
+	properties, err := h.service.Properties(r.Context(), r.PathValue("id"), asOf)
+	if !h.writeError(w, err, "failed to fetch properties") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, properties)
+}
+
+// Statement returns the owner's statement for the month in the path, as
+// JSON or, with format=csv, as a spreadsheet download.
+func (h *OwnerHandler) Statement(w http.ResponseWriter, r *http.Request) {
+	month, err := time.Parse("2006-01", r.PathValue("month"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid month format, use YYYY-MM")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		response.Error(w, http.StatusBadRequest, "invalid format, use json or csv")
+		return
+	}
+
+	statement, err := h.service.Statement(r.Context(), r.PathValue("id"), month)
+	if !h.writeError(w, err, "failed to build statement") {
+		return
+	}
+
+	if format == "csv" {
+		writeStatementCSV(w, statement)
+		return
+	}
+	response.JSON(w, http.StatusOK, statement)
+}
+
+// writeStatementCSV writes one row per property followed by a total row.
+func writeStatementCSV(w http.ResponseWriter, statement model.OwnerStatement) {
+	filename := fmt.Sprintf("owner-statement-%s-%s.csv", statement.Owner.ID, statement.Month)
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	out := csv.NewWriter(w)
+	out.Write([]string{"property_id", "property", "rent_collected", "management_fee", "expenses", "net"})
+	for _, line := range statement.Properties {
+		out.Write(statementRow(line.PropertyID, line.Property, line.OwnerStatementAmounts))
+	}
+	out.Write(statementRow("", "total", statement.Totals))
+	out.Flush()
+}
+
+func statementRow(propertyID, property string, amounts model.OwnerStatementAmounts) []string {
+	return []string{
+		propertyID,
+		property,
+		formatAmount(amounts.RentCollected),
+		formatAmount(amounts.ManagementFee),
+		formatAmount(amounts.Expenses),
+		formatAmount(amounts.Net),
+	}
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// writeError maps an owner service error to a response. It returns true
+// if err is nil and the caller should carry on.
+func (h *OwnerHandler) writeError(w http.ResponseWriter, err error, fallback string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrOwnerNotFound):
+		response.Error(w, http.StatusNotFound, "owner not found")
+	case errors.Is(err, service.ErrOwnerHasProperties), errors.Is(err, service.ErrOwnerUserTaken):
+		response.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidInput):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+	default:
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+	return false
+}
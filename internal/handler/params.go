@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+// dateQuery parses an optional YYYY-MM-DD query parameter, returning def
+// when the parameter is absent.
+func dateQuery(r *http.Request, name string, def time.Time) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+// intQuery parses an optional integer query parameter, returning nil when
+// the parameter is absent.
+func intQuery(r *http.Request, name string) (*int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// floatQuery parses an optional decimal query parameter, returning nil when
+// the parameter is absent.
+func floatQuery(r *http.Request, name string) (*float64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// boolQuery parses an optional true/false query parameter, returning nil
+// when the parameter is absent.
+func boolQuery(r *http.Request, name string) (*bool, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// listQuery splits a comma-separated query parameter, returning nil when the
+// parameter is absent.
+func listQuery(r *http.Request, name string) []string {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// optionalDateQuery parses an optional YYYY-MM-DD query parameter, returning
+// nil when the parameter is absent.
+func optionalDateQuery(r *http.Request, name string) (*time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// pageRequest reads the cursor, limit, sort and order query parameters.
+// Without a sort the listing is newest first; with one it defaults to
+// ascending order.
+func pageRequest(r *http.Request) (model.PageRequest, error) {
+	q := r.URL.Query()
+	page := model.PageRequest{
+		Cursor: q.Get("cursor"),
+		Sort:   q.Get("sort"),
+	}
+
+	limit, err := intQuery(r, "limit")
+	if err != nil {
+		return model.PageRequest{}, errors.New("invalid limit")
+	}
+	if limit != nil {
+		page.Limit = *limit
+		if page.Limit <= 0 {
+			return model.PageRequest{}, errors.New("limit must be positive")
+		}
+	}
+
+	switch q.Get("order") {
+	case "":
+		page.Desc = page.Sort == ""
+	case "asc":
+	case "desc":
+		page.Desc = true
+	default:
+		return model.PageRequest{}, errors.New("invalid order, use asc or desc")
+	}
+	return page, nil
+}
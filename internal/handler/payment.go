@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type PaymentHandler struct {
+	service *service.PaymentService
+}
+
+func NewPaymentHandler(s *service.PaymentService) *PaymentHandler {
+	return &PaymentHandler{service: s}
+}
+
+func (h *PaymentHandler) GetByLease(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	payments, err := h.service.GetByLeaseID(r.Context(), leaseID)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch payments")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, payments)
+}
+
+func (h *PaymentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	var input struct {
+		Amount    float64 `json:"amount"`
+		PaidAt    string  `json:"paid_at"`
+		Method    string  `json:"method"`
+		Reference string  `json:"reference"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	paidAt, err := time.Parse("2006-01-02", input.PaidAt)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid paid_at format, use YYYY-MM-DD")
+		return
+	}
+
+	payment, err := h.service.Create(r.Context(), leaseID, input.Amount, paidAt, input.Method, input.Reference)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to record payment")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, payment)
+}
+
+func (h *PaymentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := h.service.Delete(r.Context(), id)
+	if errors.Is(err, service.ErrPaymentNotFound) {
+		response.Error(w, http.StatusNotFound, "payment not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete payment")
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *PaymentHandler) Ledger(w http.ResponseWriter, r *http.Request) {
+	leaseID := r.PathValue("id")
+
+	asOf, err := dateQuery(r, "as_of", time.Now().UTC())
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid as_of format, use YYYY-MM-DD")
+		return
+	}
+
+	ledger, err := h.service.Ledger(r.Context(), leaseID, asOf)
+	if errors.Is(err, service.ErrLeaseNotFound) {
+		response.Error(w, http.StatusNotFound, "lease not found")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to build ledger")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ledger)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+// PortalHandler serves the tenant self-service endpoints under /me. The
+// endpoints that work on one lease take an optional lease_id and default
+// to the tenant's current lease.
+type PortalHandler struct {
+	service *service.PortalService
+}
+
+func NewPortalHandler(s *service.PortalService) *PortalHandler {
+	return &PortalHandler{service: s}
+}
+
+func (h *PortalHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	tenant, err := h.service.Profile(r.Context())
+	if !h.writeError(w, err, "failed to fetch profile") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tenant)
+}
+
+func (h *PortalHandler) Leases(w http.ResponseWriter, r *http.Request) {
+	leases, err := h.service.Leases(r.Context())
+	if !h.writeError(w, err, "failed to fetch leases") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, leases)
+}
+
+func (h *PortalHandler) Lease(w http.ResponseWriter, r *http.Request) {
+	lease, err := h.service.Lease(r.Context(), r.URL.Query().Get("lease_id"))
+	if !h.writeError(w, err, "failed to fetch lease") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, lease)
+}
+
+func (h *PortalHandler) Payments(w http.ResponseWriter, r *http.Request) {
+	payments, err := h.service.Payments(r.Context(), r.URL.Query().Get("lease_id"))
+	if !h.writeError(w, err, "failed to fetch payments") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, payments)
+}
+
+func (h *PortalHandler) Invoices(w http.ResponseWriter, r *http.Request) {
+	invoices, err := h.service.Invoices(r.Context(), r.URL.Query().Get("lease_id"))
+	if !h.writeError(w, err, "failed to fetch invoices") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invoices)
+}
+
+func (h *PortalHandler) Ledger(w http.ResponseWriter, r *http.Request) {
+	asOf, err := dateQuery(r, "as_of", time.Now().UTC())
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid as_of format, use YYYY-MM-DD")
+		return
+	}
+
+	ledger, err := h.service.Ledger(r.Context(), r.URL.Query().Get("lease_id"), asOf)
+	if !h.writeError(w, err, "failed to build ledger") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ledger)
+}
+
+func (h *PortalHandler) Documents(w http.ResponseWriter, r *http.Request) {
+	documents, err := h.service.Documents(r.Context())
+	if !h.writeError(w, err, "failed to fetch documents") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, documents)
+}
+
+func (h *PortalHandler) Requests(w http.ResponseWriter, r *http.Request) {
+	requests, err := h.service.Requests(r.Context())
+	if !h.writeError(w, err, "failed to fetch requests") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, requests)
+}
+
+func (h *PortalHandler) Request(w http.ResponseWriter, r *http.Request) {
+	request, err := h.service.Request(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch request") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, request)
+}
+
+func (h *PortalHandler) SubmitRequest(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	request, err := h.service.SubmitRequest(r.Context(), input.Title, input.Description)
+	if !h.writeError(w, err, "failed to submit request") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, request)
+}
+
+// writeError maps a portal service error to a response. It returns true
+// if err is nil and the caller should carry on.
+func (h *PortalHandler) writeError(w http.ResponseWriter, err error, fallback string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "only tenants can use the tenant portal")
+	case errors.Is(err, service.ErrTenantNotFound):
+		response.Error(w, http.StatusNotFound, "tenant not found")
+	case errors.Is(err, service.ErrLeaseNotFound):
+		response.Error(w, http.StatusNotFound, "lease not found")
+	case errors.Is(err, service.ErrMaintenanceRequestNotFound):
+		response.Error(w, http.StatusNotFound, "request not found")
+	case errors.Is(err, service.ErrInvalidInput):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+	return false
+}
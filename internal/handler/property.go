@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
+	"github.com/Lacsw/rntly/internal/model"
 	"github.com/Lacsw/rntly/internal/response"
 	"github.com/Lacsw/rntly/internal/service"
 )
@@ -17,8 +19,115 @@ func NewPropertyHandler(s *service.PropertyService) *PropertyHandler {
 	return &PropertyHandler{service: s}
 }
 
+type propertyInput struct {
+	BuildingID    *string       `json:"building_id"`
+	UnitNumber    *string       `json:"unit_number"`
+	OwnerID       *string       `json:"owner_id"`
+	Address       model.Address `json:"address"`
+	Type          string        `json:"type"`
+	Bedrooms      int           `json:"bedrooms"`
+	Bathrooms     float64       `json:"bathrooms"`
+	Area          *float64      `json:"area"`
+	Floor         *int          `json:"floor"`
+	Furnished     bool          `json:"furnished"`
+	ParkingSpaces int           `json:"parking_spaces"`
+	PetPolicy     string        `json:"pet_policy"`
+	Amenities     []string      `json:"amenities"`
+	RentAmount    float64       `json:"rent_amount"`
+}
+
+func (in propertyInput) details() service.PropertyDetails {
+	return service.PropertyDetails{
+		BuildingID:    in.BuildingID,
+		UnitNumber:    in.UnitNumber,
+		OwnerID:       in.OwnerID,
+		Address:       in.Address,
+		Type:          in.Type,
+		Bedrooms:      in.Bedrooms,
+		Bathrooms:     in.Bathrooms,
+		Area:          in.Area,
+		Floor:         in.Floor,
+		Furnished:     in.Furnished,
+		ParkingSpaces: in.ParkingSpaces,
+		PetPolicy:     in.PetPolicy,
+		Amenities:     in.Amenities,
+		RentAmount:    in.RentAmount,
+	}
+}
+
+// propertyFilter reads the listing filters from the query string.
+func propertyFilter(r *http.Request) (model.PropertyFilter, error) {
+	q := r.URL.Query()
+	filter := model.PropertyFilter{
+		Status:     q.Get("status"),
+		BuildingID: q.Get("building_id"),
+		OwnerID:    q.Get("owner_id"),
+		City:       q.Get("city"),
+		PostalCode: q.Get("postal_code"),
+		Type:       q.Get("type"),
+		PetPolicy:  q.Get("pet_policy"),
+		Amenities:  listQuery(r, "amenities"),
+	}
+
+	var err error
+	if filter.MinBedrooms, err = intQuery(r, "min_bedrooms"); err != nil {
+		return filter, errors.New("invalid min_bedrooms")
+	}
+	if filter.MaxBedrooms, err = intQuery(r, "max_bedrooms"); err != nil {
+		return filter, errors.New("invalid max_bedrooms")
+	}
+	if filter.MinBathrooms, err = floatQuery(r, "min_bathrooms"); err != nil {
+		return filter, errors.New("invalid min_bathrooms")
+	}
+	if filter.MinArea, err = floatQuery(r, "min_area"); err != nil {
+		return filter, errors.New("invalid min_area")
+	}
+	if filter.MaxArea, err = floatQuery(r, "max_area"); err != nil {
+		return filter, errors.New("invalid max_area")
+	}
+	if filter.MinRent, err = floatQuery(r, "min_rent"); err != nil {
+		return filter, errors.New("invalid min_rent")
+	}
+	if filter.MaxRent, err = floatQuery(r, "max_rent"); err != nil {
+		return filter, errors.New("invalid max_rent")
+	}
+	if filter.Furnished, err = boolQuery(r, "furnished"); err != nil {
+		return filter, errors.New("invalid furnished, use true or false")
+	}
+	if filter.HasParking, err = boolQuery(r, "parking"); err != nil {
+		return filter, errors.New("invalid parking, use true or false")
+	}
+	return filter, nil
+}
+
 func (h *PropertyHandler) List(w http.ResponseWriter, r *http.Request) {
-	properties, err := h.service.List(r.Context())
+	asOf, err := dateQuery(r, "as_of", time.Now().UTC())
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid as_of format, use YYYY-MM-DD")
+		return
+	}
+
+	filter, err := propertyFilter(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := pageRequest(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	properties, err := h.service.List(r.Context(), asOf, filter, page)
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch properties")
 		return
@@ -30,11 +139,21 @@ func (h *PropertyHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *PropertyHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	property, err := h.service.GetByID(r.Context(), id)
+	asOf, err := dateQuery(r, "as_of", time.Now().UTC())
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid as_of format, use YYYY-MM-DD")
+		return
+	}
+
+	property, err := h.service.GetByID(r.Context(), id, asOf)
 	if errors.Is(err, service.ErrPropertyNotFound) {
 		response.Error(w, http.StatusNotFound, "property not found")
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch property")
 		return
@@ -44,23 +163,26 @@ func (h *PropertyHandler) Get(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *PropertyHandler) Create(w http.ResponseWriter, r *http.Request) {
-	var input struct {
-		Address    string  `json:"address"`
-		Type       string  `json:"type"`
-		Bedrooms   int     `json:"bedrooms"`
-		RentAmount float64 `json:"rent_amount"`
-	}
+	var input propertyInput
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		response.Error(w, http.StatusBadRequest, "invalid JSON")
 		return
 	}
 
-	property, err := h.service.Create(r.Context(), input.Address, input.Type, input.Bedrooms, input.RentAmount)
+	property, err := h.service.Create(r.Context(), input.details())
 	if errors.Is(err, service.ErrInvalidInput) {
 		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if errors.Is(err, service.ErrDuplicateUnit) {
+		response.Error(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to create property")
 		return
@@ -72,20 +194,14 @@ func (h *PropertyHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *PropertyHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	var input struct {
-		Address    string  `json:"address"`
-		Type       string  `json:"type"`
-		Bedrooms   int     `json:"bedrooms"`
-		RentAmount float64 `json:"rent_amount"`
-		Status     string  `json:"status"`
-	}
+	var input propertyInput
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		response.Error(w, http.StatusBadRequest, "invalid JSON")
 		return
 	}
 
-	property, err := h.service.Update(r.Context(), id, input.Address, input.Type, input.Bedrooms, input.RentAmount, input.Status)
+	property, err := h.service.Update(r.Context(), id, input.details())
 	if errors.Is(err, service.ErrPropertyNotFound) {
 		response.Error(w, http.StatusNotFound, "property not found")
 		return
@@ -94,6 +210,14 @@ func (h *PropertyHandler) Update(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if errors.Is(err, service.ErrDuplicateUnit) {
+		response.Error(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to update property")
 		return
@@ -110,6 +234,10 @@ func (h *PropertyHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusNotFound, "property not found")
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to delete property")
 		return
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type SearchHandler struct {
+	service *service.SearchService
+}
+
+func NewSearchHandler(s *service.SearchService) *SearchHandler {
+	return &SearchHandler{service: s}
+}
+
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	limit, err := intQuery(r, "limit")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	n := 0
+	if limit != nil {
+		n = *limit
+	}
+
+	results, err := h.service.Search(r.Context(), r.URL.Query().Get("q"), listQuery(r, "types"), n)
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to search")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, results)
+}
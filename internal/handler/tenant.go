@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/Lacsw/rntly/internal/model"
 	"github.com/Lacsw/rntly/internal/response"
 	"github.com/Lacsw/rntly/internal/service"
 )
@@ -18,7 +19,28 @@ func NewTenantHandler(s *service.TenantService) *TenantHandler {
 }
 
 func (h *TenantHandler) List(w http.ResponseWriter, r *http.Request) {
-	tenants, err := h.service.List(r.Context())
+	page, err := pageRequest(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := model.TenantFilter{
+		Name:       r.URL.Query().Get("name"),
+		Email:      r.URL.Query().Get("email"),
+		City:       r.URL.Query().Get("city"),
+		PostalCode: r.URL.Query().Get("postal_code"),
+	}
+
+	tenants, err := h.service.List(r.Context(), filter, page)
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch tenants")
 		return
@@ -35,6 +57,10 @@ func (h *TenantHandler) Get(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusNotFound, "tenant not found")
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to fetch tenant")
 		return
@@ -45,10 +71,11 @@ func (h *TenantHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 func (h *TenantHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
-		Email     string `json:"email"`
-		Phone     string `json:"phone"`
+		FirstName string        `json:"first_name"`
+		LastName  string        `json:"last_name"`
+		Email     string        `json:"email"`
+		Phone     string        `json:"phone"`
+		Address   model.Address `json:"address"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
@@ -56,11 +83,15 @@ func (h *TenantHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tenant, err := h.service.Create(r.Context(), input.FirstName, input.LastName, input.Email, input.Phone)
+	tenant, err := h.service.Create(r.Context(), input.FirstName, input.LastName, input.Email, input.Phone, input.Address)
 	if errors.Is(err, service.ErrInvalidInput) {
 		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to create tenant")
 		return
@@ -73,10 +104,11 @@ func (h *TenantHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
 	var input struct {
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
-		Email     string `json:"email"`
-		Phone     string `json:"phone"`
+		FirstName string        `json:"first_name"`
+		LastName  string        `json:"last_name"`
+		Email     string        `json:"email"`
+		Phone     string        `json:"phone"`
+		Address   model.Address `json:"address"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
@@ -84,7 +116,7 @@ func (h *TenantHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tenant, err := h.service.Update(r.Context(), id, input.FirstName, input.LastName, input.Email, input.Phone)
+	tenant, err := h.service.Update(r.Context(), id, input.FirstName, input.LastName, input.Email, input.Phone, input.Address)
 	if errors.Is(err, service.ErrTenantNotFound) {
 		response.Error(w, http.StatusNotFound, "tenant not found")
 		return
@@ -93,6 +125,10 @@ func (h *TenantHandler) Update(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to update tenant")
 		return
@@ -109,6 +145,14 @@ func (h *TenantHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, http.StatusNotFound, "tenant not found")
 		return
 	}
+	if errors.Is(err, service.ErrTenantOnLease) {
+		response.Error(w, http.StatusConflict, "tenant is still a party to a lease")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "failed to delete tenant")
 		return
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type UserHandler struct {
+	service *service.UserService
+}
+
+func NewUserHandler(s *service.UserService) *UserHandler {
+	return &UserHandler{service: s}
+}
+
+func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
+	users, err := h.service.List(r.Context())
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch users")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, users)
+}
+
+func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	user, err := h.service.GetByID(r.Context(), id)
+	if errors.Is(err, service.ErrUserNotFound) {
+		response.Error(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to fetch user")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, user)
+}
+
+func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Email    string  `json:"email"`
+		Name     string  `json:"name"`
+		Password string  `json:"password"`
+		Role     string  `json:"role"`
+		TenantID *string `json:"tenant_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	user, err := h.service.Create(r.Context(), input.Email, input.Name, input.Password, input.Role, input.TenantID)
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrEmailTaken) {
+		response.Error(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to create user")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, user)
+}
+
+func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input struct {
+		Name     string  `json:"name"`
+		Role     string  `json:"role"`
+		TenantID *string `json:"tenant_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	user, err := h.service.Update(r.Context(), id, input.Name, input.Role, input.TenantID)
+	if errors.Is(err, service.ErrUserNotFound) {
+		response.Error(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidInput) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to update user")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, user)
+}
+
+func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	current, _ := auth.UserFrom(r.Context())
+
+	err := h.service.Delete(r.Context(), current.ID, id)
+	if errors.Is(err, service.ErrUserNotFound) {
+		response.Error(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if errors.Is(err, service.ErrDeleteSelf) {
+		response.Error(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+		return
+	}
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "failed to delete user")
+		return
+	}
+
+	response.NoContent(w)
+}
@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+type VendorHandler struct {
+	service *service.VendorService
+}
+
+func NewVendorHandler(s *service.VendorService) *VendorHandler {
+	return &VendorHandler{service: s}
+}
+
+type vendorInput struct {
+	Name               string   `json:"name"`
+	Trade              string   `json:"trade"`
+	ContactName        string   `json:"contact_name"`
+	Email              string   `json:"email"`
+	Phone              string   `json:"phone"`
+	InsuranceExpiresOn string   `json:"insurance_expires_on"`
+	HourlyRate         *float64 `json:"hourly_rate"`
+}
+
+func (in vendorInput) details() (service.VendorDetails, error) {
+	expiresOn, err := optionalDate(in.InsuranceExpiresOn)
+	if err != nil {
+		return service.VendorDetails{}, errors.New("invalid insurance_expires_on format, use YYYY-MM-DD")
+	}
+
+	return service.VendorDetails{
+		Name:               in.Name,
+		Trade:              in.Trade,
+		ContactName:        in.ContactName,
+		Email:              in.Email,
+		Phone:              in.Phone,
+		InsuranceExpiresOn: expiresOn,
+		HourlyRate:         in.HourlyRate,
+	}, nil
+}
+
+func (h *VendorHandler) List(w http.ResponseWriter, r *http.Request) {
+	lapsed, err := boolQuery(r, "insurance_lapsed")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid insurance_lapsed, use true or false")
+		return
+	}
+
+	filter := model.VendorFilter{
+		Trade:           r.URL.Query().Get("trade"),
+		InsuranceLapsed: lapsed,
+	}
+
+	vendors, err := h.service.List(r.Context(), filter)
+	if !h.writeError(w, err, "failed to fetch vendors") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, vendors)
+}
+
+func (h *VendorHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vendor, err := h.service.GetByID(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch vendor") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, vendor)
+}
+
+func (h *VendorHandler) GetByProperty(w http.ResponseWriter, r *http.Request) {
+	vendors, err := h.service.GetByPropertyID(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch vendors") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, vendors)
+}
+
+func (h *VendorHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input vendorInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	details, err := input.details()
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	vendor, err := h.service.Create(r.Context(), details)
+	if !h.writeError(w, err, "failed to create vendor") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, vendor)
+}
+
+func (h *VendorHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var input vendorInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	details, err := input.details()
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	vendor, err := h.service.Update(r.Context(), r.PathValue("id"), details)
+	if !h.writeError(w, err, "failed to update vendor") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, vendor)
+}
+
+func (h *VendorHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	err := h.service.Delete(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to delete vendor") {
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *VendorHandler) Jobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.service.Jobs(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch jobs") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, jobs)
+}
+
+func (h *VendorHandler) PropertyJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.service.PropertyJobs(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch jobs") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, jobs)
+}
+
+func (h *VendorHandler) RecordJob(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		PropertyID           string   `json:"property_id"`
+		MaintenanceRequestID *string  `json:"maintenance_request_id"`
+		Description          string   `json:"description"`
+		PerformedOn          string   `json:"performed_on"`
+		Hours                *float64 `json:"hours"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	performedOn, err := time.Parse("2006-01-02", input.PerformedOn)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid performed_on format, use YYYY-MM-DD")
+		return
+	}
+
+	job, err := h.service.RecordJob(r.Context(), r.PathValue("id"), service.JobDetails{
+		PropertyID:           input.PropertyID,
+		MaintenanceRequestID: input.MaintenanceRequestID,
+		Description:          input.Description,
+		PerformedOn:          performedOn,
+		Hours:                input.Hours,
+	})
+	if !h.writeError(w, err, "failed to record job") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, job)
+}
+
+func (h *VendorHandler) DeleteJob(w http.ResponseWriter, r *http.Request) {
+	err := h.service.DeleteJob(r.Context(), r.PathValue("id"), r.PathValue("jobId"))
+	if !h.writeError(w, err, "failed to delete job") {
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *VendorHandler) Invoices(w http.ResponseWriter, r *http.Request) {
+	invoices, err := h.service.Invoices(r.Context(), r.PathValue("id"))
+	if !h.writeError(w, err, "failed to fetch invoices") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invoices)
+}
+
+func (h *VendorHandler) AddInvoice(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		JobID    *string `json:"job_id"`
+		Number   string  `json:"number"`
+		IssuedOn string  `json:"issued_on"`
+		DueOn    string  `json:"due_on"`
+		Amount   float64 `json:"amount"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	issuedOn, err := time.Parse("2006-01-02", input.IssuedOn)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid issued_on format, use YYYY-MM-DD")
+		return
+	}
+	dueOn, err := optionalDate(input.DueOn)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid due_on format, use YYYY-MM-DD")
+		return
+	}
+
+	invoice, err := h.service.AddInvoice(r.Context(), r.PathValue("id"), service.InvoiceDetails{
+		JobID:    input.JobID,
+		Number:   input.Number,
+		IssuedOn: issuedOn,
+		DueOn:    dueOn,
+		Amount:   input.Amount,
+	})
+	if !h.writeError(w, err, "failed to add invoice") {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, invoice)
+}
+
+func (h *VendorHandler) PayInvoice(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		PaidOn string `json:"paid_on"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	paidOn, err := time.Parse("2006-01-02", input.PaidOn)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid paid_on format, use YYYY-MM-DD")
+		return
+	}
+
+	invoice, err := h.service.PayInvoice(r.Context(), r.PathValue("id"), r.PathValue("invoiceId"), paidOn)
+	if !h.writeError(w, err, "failed to pay invoice") {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invoice)
+}
+
+func (h *VendorHandler) DeleteInvoice(w http.ResponseWriter, r *http.Request) {
+	err := h.service.DeleteInvoice(r.Context(), r.PathValue("id"), r.PathValue("invoiceId"))
+	if !h.writeError(w, err, "failed to delete invoice") {
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// writeError maps a vendor service error to a response. It returns true
+// if err is nil and the caller should carry on.
+func (h *VendorHandler) writeError(w http.ResponseWriter, err error, fallback string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrVendorNotFound):
+		response.Error(w, http.StatusNotFound, "vendor not found")
+	case errors.Is(err, service.ErrPropertyNotFound):
+		response.Error(w, http.StatusNotFound, "property not found")
+	case errors.Is(err, service.ErrVendorJobNotFound):
+		response.Error(w, http.StatusNotFound, "job not found")
+	case errors.Is(err, service.ErrVendorInvoiceNotFound):
+		response.Error(w, http.StatusNotFound, "invoice not found")
+	case errors.Is(err, service.ErrVendorInvoicePaid):
+		response.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidInput):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+	default:
+		response.Error(w, http.StatusInternalServerError, fallback)
+	}
+	return false
+}
+
+// optionalDate parses a YYYY-MM-DD date, returning nil for an empty string.
+func optionalDate(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/response"
+	"github.com/Lacsw/rntly/internal/service"
+)
+
+// publicPaths can be reached without signing in.
+var publicPaths = map[string]bool{
+	"/health":     true,
+	"/auth/login": true,
+}
+
+// Auth rejects requests without a valid session token and puts the
+// signed-in user into the context of the rest.
+func Auth(authService *service.AuthService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if publicPaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := authService.Authenticate(r.Context(), auth.BearerToken(r))
+			if errors.Is(err, service.ErrUnauthenticated) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				response.Error(w, http.StatusUnauthorized, "authentication required")
+				return
+			}
+			if err != nil {
+				log.Printf("authenticate: %v", err)
+				response.Error(w, http.StatusInternalServerError, "failed to authenticate")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithUser(r.Context(), user)))
+		})
+	}
+}
+
+// Require rejects requests from users whose role lacks the permission. It
+// guards routes whose services make no finer checks of their own.
+func Require(perm auth.Permission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !auth.Allowed(r.Context(), perm) {
+			response.Error(w, http.StatusForbidden, "not allowed to perform this action")
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,16 @@
+package model
+
+// Address is a postal address. Country is an ISO 3166-1 alpha-2 code.
+type Address struct {
+	Street     string `json:"street"`
+	Unit       string `json:"unit"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// IsZero reports whether no part of the address is set.
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Application is a prospect's request to rent a property. It moves from
+// "submitted" through "under_review" to "approved", "rejected" or
+// "withdrawn"; approval creates the tenant and lease recorded in TenantID
+// and LeaseID.
+type Application struct {
+	ID               string     `json:"id"`
+	OrganizationID   string     `json:"organization_id"`
+	PropertyID       string     `json:"property_id"`
+	FirstName        string     `json:"first_name"`
+	LastName         string     `json:"last_name"`
+	Email            string     `json:"email"`
+	Phone            string     `json:"phone"`
+	Employer         string     `json:"employer"`
+	JobTitle         string     `json:"job_title"`
+	MonthlyIncome    float64    `json:"monthly_income"`
+	DesiredStartDate time.Time  `json:"desired_start_date"`
+	DesiredEndDate   time.Time  `json:"desired_end_date"`
+	Status           string     `json:"status"`
+	DecisionReason   string     `json:"decision_reason"`
+	DecidedAt        *time.Time `json:"decided_at"`
+	TenantID         *string    `json:"tenant_id"`
+	LeaseID          *string    `json:"lease_id"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
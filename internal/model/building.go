@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Building groups properties that share an address, such as the units of an
+// apartment block. One with an OwnerID is managed on behalf of that owner.
+type Building struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Name           string    `json:"name"`
+	Address        Address   `json:"address"`
+	OwnerID        *string   `json:"owner_id"`
+	Amenities      []string  `json:"amenities"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// OccupancySummary aggregates the occupancy of a building's units on a date.
+// RentRoll is the monthly rent of the occupied units.
+type OccupancySummary struct {
+	TotalUnits    int     `json:"total_units"`
+	OccupiedUnits int     `json:"occupied_units"`
+	VacantUnits   int     `json:"vacant_units"`
+	OccupancyRate float64 `json:"occupancy_rate"`
+	RentRoll      float64 `json:"rent_roll"`
+}
+
+// BuildingUnits is a building with its units as of a date.
+type BuildingUnits struct {
+	Building
+	AsOf    time.Time        `json:"as_of"`
+	Summary OccupancySummary `json:"summary"`
+	Units   []Property       `json:"units"`
+}
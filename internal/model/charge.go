@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// LeaseCharge is an amount billed to a lease on top of scheduled rent, such
+// as a late fee. InvoiceID links the charge to the invoice that caused it.
+type LeaseCharge struct {
+	ID          string    `json:"id"`
+	LeaseID     string    `json:"lease_id"`
+	InvoiceID   *string   `json:"invoice_id"`
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	ChargeDate  time.Time `json:"charge_date"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// DepositSettlement tracks the return of a lease's security deposit once the
+// lease has ended. The totals and deadline fields are computed when the
+// settlement is read and are not stored.
+type DepositSettlement struct {
+	ID                string             `json:"id"`
+	LeaseID           string             `json:"lease_id"`
+	DepositAmount     float64            `json:"deposit_amount"`
+	Status            string             `json:"status"`
+	Deadline          time.Time          `json:"deadline"`
+	SettledAt         *time.Time         `json:"settled_at"`
+	Deductions        []DepositDeduction `json:"deductions"`
+	TotalDeductions   float64            `json:"total_deductions"`
+	RefundAmount      float64            `json:"refund_amount"`
+	AmountOwed        float64            `json:"amount_owed"`
+	DaysUntilDeadline int                `json:"days_until_deadline"`
+	Overdue           bool               `json:"overdue"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
+type DepositDeduction struct {
+	ID           string    `json:"id"`
+	SettlementID string    `json:"settlement_id"`
+	Category     string    `json:"category"`
+	Description  string    `json:"description"`
+	Amount       float64   `json:"amount"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Document links a file kept outside rntly, such as a signed agreement or a
+// notice, to the lease it belongs to. Every party to the lease can see it.
+type Document struct {
+	ID        string    `json:"id"`
+	LeaseID   string    `json:"lease_id"`
+	Title     string    `json:"title"`
+	Category  string    `json:"category"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
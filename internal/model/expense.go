@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Expense is money spent on a property, such as a repair or an insurance
+// premium. Expenses are deducted in the owner's statements. An expense with
+// a MaintenanceRequestID is the cost of that repair.
+type Expense struct {
+	ID                   string    `json:"id"`
+	PropertyID           string    `json:"property_id"`
+	MaintenanceRequestID *string   `json:"maintenance_request_id"`
+	IncurredOn           time.Time `json:"incurred_on"`
+	Category             string    `json:"category"`
+	Description          string    `json:"description"`
+	Amount               float64   `json:"amount"`
+	CreatedAt            time.Time `json:"created_at"`
+}
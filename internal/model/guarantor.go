@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Guarantor is a person who is liable for a lease without living in the
+// unit. A nil LiabilityCap means the guarantee is unlimited.
+type Guarantor struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+	Email          string    `json:"email"`
+	Phone          string    `json:"phone"`
+	Address        string    `json:"address"`
+	AnnualIncome   *float64  `json:"annual_income"`
+	LiabilityCap   *float64  `json:"liability_cap"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type Invoice struct {
+	ID          string    `json:"id"`
+	LeaseID     string    `json:"lease_id"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	DueDate     time.Time `json:"due_date"`
+	Amount      float64   `json:"amount"`
+	AmountPaid  float64   `json:"amount_paid"`
+	AmountDue   float64   `json:"amount_due"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
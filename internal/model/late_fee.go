@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// LateFeePolicy describes how overdue rent is penalised. It is attached to
+// either a property or a single lease; a lease policy overrides the one on
+// its property. A nil MaxFee means fees are not capped.
+type LateFeePolicy struct {
+	ID         string    `json:"id"`
+	PropertyID *string   `json:"property_id"`
+	LeaseID    *string   `json:"lease_id"`
+	GraceDays  int       `json:"grace_days"`
+	FlatFee    float64   `json:"flat_fee"`
+	Percent    float64   `json:"percent"`
+	DailyFee   float64   `json:"daily_fee"`
+	MaxFee     *float64  `json:"max_fee"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
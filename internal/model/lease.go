@@ -3,14 +3,50 @@ package model
 import "time"
 
 type Lease struct {
-	ID         string    `json:"id"`
-	PropertyID string    `json:"property_id"`
-	TenantID   string    `json:"tenant_id"`
-	StartDate  time.Time `json:"start_date"`
-	EndDate    time.Time `json:"end_date"`
-	RentAmount float64   `json:"rent_amount"`
-	Deposit    float64   `json:"deposit"`
-	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID              string            `json:"id"`
+	OrganizationID  string            `json:"organization_id"`
+	PropertyID      string            `json:"property_id"`
+	TenantID        string            `json:"tenant_id"`
+	StartDate       time.Time         `json:"start_date"`
+	EndDate         time.Time         `json:"end_date"`
+	RentAmount      float64           `json:"rent_amount"`
+	Deposit         float64           `json:"deposit"`
+	Status          string            `json:"status"`
+	PreviousLeaseID *string           `json:"previous_lease_id"`
+	Termination     *LeaseTermination `json:"termination"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// LeaseTermination records a notice to vacate or an early termination.
+// OriginalEndDate keeps the contractual end date after EndDate has been
+// moved up to the move-out date.
+type LeaseTermination struct {
+	NoticeDate      time.Time `json:"notice_date"`
+	MoveOutDate     time.Time `json:"move_out_date"`
+	Reason          string    `json:"reason"`
+	Fee             float64   `json:"fee"`
+	OriginalEndDate time.Time `json:"original_end_date"`
+}
+
+// LeaseFilter narrows a lease listing. Zero and nil fields match everything;
+// TenantID matches leases the tenant is a party to in any role, and the date
+// bounds are inclusive.
+type LeaseFilter struct {
+	Status     string
+	PropertyID string
+	TenantID   string
+	MinRent    *float64
+	MaxRent    *float64
+	StartFrom  *time.Time
+	StartTo    *time.Time
+	EndFrom    *time.Time
+	EndTo      *time.Time
+}
+
+// LeaseDetail is a lease together with everyone attached to it.
+type LeaseDetail struct {
+	Lease
+	Parties    []LeaseParty `json:"parties"`
+	Guarantors []Guarantor  `json:"guarantors"`
 }
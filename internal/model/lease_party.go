@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// LeaseParty links a tenant to a lease in a given role: "primary",
+// "co_tenant", "guarantor" or "occupant". The primary party is the lease's
+// TenantID. A guarantor party is also linked to the lease as a Guarantor.
+type LeaseParty struct {
+	LeaseID   string    `json:"lease_id"`
+	TenantID  string    `json:"tenant_id"`
+	Role      string    `json:"role"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	CreatedAt time.Time `json:"created_at"`
+}
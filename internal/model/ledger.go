@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// LedgerEntry is a single line of a lease ledger. Charges carry a positive
+// amount and payments a negative one, so Balance is a plain running sum.
+type LedgerEntry struct {
+	Date        time.Time `json:"date"`
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	Balance     float64   `json:"balance"`
+	PaymentID   string    `json:"payment_id,omitempty"`
+}
+
+type Ledger struct {
+	LeaseID      string        `json:"lease_id"`
+	AsOf         time.Time     `json:"as_of"`
+	TotalCharged float64       `json:"total_charged"`
+	TotalPaid    float64       `json:"total_paid"`
+	Balance      float64       `json:"balance"`
+	Entries      []LedgerEntry `json:"entries"`
+}
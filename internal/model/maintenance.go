@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// MaintenanceRequest reports something at a property that needs fixing.
+// LeaseID and TenantID are set when a tenant reported it. TotalCost is the
+// sum of the costs recorded against the request and is not stored.
+type MaintenanceRequest struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organization_id"`
+	PropertyID     string     `json:"property_id"`
+	LeaseID        *string    `json:"lease_id"`
+	TenantID       *string    `json:"tenant_id"`
+	VendorID       *string    `json:"vendor_id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Priority       string     `json:"priority"`
+	Category       string     `json:"category"`
+	Status         string     `json:"status"`
+	TotalCost      float64    `json:"total_cost"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	ClosedAt       *time.Time `json:"closed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// MaintenanceFilter narrows a maintenance request listing. Empty fields
+// match everything.
+type MaintenanceFilter struct {
+	PropertyID string
+	VendorID   string
+	Status     string
+	Priority   string
+	Category   string
+}
+
+// MaintenanceComment is a note left on a maintenance request. AuthorName
+// is read from the author's account and is empty once it is deleted.
+type MaintenanceComment struct {
+	ID         string    `json:"id"`
+	RequestID  string    `json:"request_id"`
+	AuthorID   *string   `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// DefaultOrganizationID is the organization that owns everything created
+// before rntly served several landlords. Its staff administer the
+// deployment and provision the other organizations.
+const DefaultOrganizationID = "default"
+
+// Organization is a landlord business. Every property, tenant and lease
+// belongs to exactly one, and users only ever see their own organization's
+// records.
+type Organization struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// Owner is a third party whose properties are managed on their behalf, in
+// return for ManagementFeePercent of the rent collected. UserID is the
+// owner-role account they sign in with, if any.
+type Owner struct {
+	ID                   string    `json:"id"`
+	OrganizationID       string    `json:"organization_id"`
+	UserID               *string   `json:"user_id"`
+	Name                 string    `json:"name"`
+	Email                string    `json:"email"`
+	Phone                string    `json:"phone"`
+	Address              Address   `json:"address"`
+	ManagementFeePercent float64   `json:"management_fee_percent"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// OwnerStatementAmounts are the money figures of an owner statement. Net is
+// what is paid out to the owner.
+type OwnerStatementAmounts struct {
+	RentCollected float64 `json:"rent_collected"`
+	ManagementFee float64 `json:"management_fee"`
+	Expenses      float64 `json:"expenses"`
+	Net           float64 `json:"net"`
+}
+
+// OwnerStatementLine is one property's part of an owner statement.
+type OwnerStatementLine struct {
+	PropertyID string `json:"property_id"`
+	Property   string `json:"property"`
+	OwnerStatementAmounts
+}
+
+// OwnerStatement rolls up a month of rent collected, management fees and
+// expenses across an owner's properties.
+type OwnerStatement struct {
+	Owner                Owner                 `json:"owner"`
+	Month                string                `json:"month"`
+	PeriodStart          time.Time             `json:"period_start"`
+	PeriodEnd            time.Time             `json:"period_end"`
+	ManagementFeePercent float64               `json:"management_fee_percent"`
+	Properties           []OwnerStatementLine  `json:"properties"`
+	Totals               OwnerStatementAmounts `json:"totals"`
+}
@@ -0,0 +1,19 @@
+package model
+
+// PageRequest selects one page of a listing. Cursor is the NextCursor of
+// the previous page, or empty for the first page. Sort names a field the
+// listing can be ordered by.
+type PageRequest struct {
+	Cursor string
+	Limit  int
+	Sort   string
+	Desc   bool
+}
+
+// Page is one page of a listing. NextCursor is nil on the last page; Total
+// counts every item matching the filters across all pages.
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+	Total      int     `json:"total"`
+}
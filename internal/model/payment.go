@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type Payment struct {
+	ID        string    `json:"id"`
+	LeaseID   string    `json:"lease_id"`
+	Amount    float64   `json:"amount"`
+	PaidAt    time.Time `json:"paid_at"`
+	Method    string    `json:"method"`
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+}
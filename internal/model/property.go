@@ -2,14 +2,53 @@ package model
 
 import "time"
 
+// Property is a rentable unit. Status, CurrentLeaseID and CurrentTenantID
+// are read-only: they are derived from the lease covering the requested date.
+// A property with a BuildingID is one of that building's units; one with an
+// OwnerID is managed on behalf of that owner.
 type Property struct {
-	ID         string    `json:"id"`
-	Address    string    `json:"address"`
-	Type       string    `json:"type"`
-	Bedrooms   int       `json:"bedrooms"`
-	Area       *float64  `json:"area"`
-	RentAmount float64   `json:"rent_amount"`
-	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID              string    `json:"id"`
+	OrganizationID  string    `json:"organization_id"`
+	BuildingID      *string   `json:"building_id"`
+	UnitNumber      *string   `json:"unit_number"`
+	OwnerID         *string   `json:"owner_id"`
+	Address         Address   `json:"address"`
+	Type            string    `json:"type"`
+	Bedrooms        int       `json:"bedrooms"`
+	Bathrooms       float64   `json:"bathrooms"`
+	Area            *float64  `json:"area"`
+	Floor           *int      `json:"floor"`
+	Furnished       bool      `json:"furnished"`
+	ParkingSpaces   int       `json:"parking_spaces"`
+	PetPolicy       string    `json:"pet_policy"`
+	Amenities       []string  `json:"amenities"`
+	RentAmount      float64   `json:"rent_amount"`
+	Status          string    `json:"status"`
+	CurrentLeaseID  *string   `json:"current_lease_id"`
+	CurrentTenantID *string   `json:"current_tenant_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// PropertyFilter narrows a property listing. Zero and nil fields match
+// everything; Status is the derived occupancy, PostalCode matches as a
+// prefix and a property must have all of the listed Amenities.
+type PropertyFilter struct {
+	Status       string
+	BuildingID   string
+	OwnerID      string
+	City         string
+	PostalCode   string
+	Type         string
+	MinBedrooms  *int
+	MaxBedrooms  *int
+	MinBathrooms *float64
+	MinArea      *float64
+	MaxArea      *float64
+	MinRent      *float64
+	MaxRent      *float64
+	Furnished    *bool
+	HasParking   *bool
+	PetPolicy    string
+	Amenities    []string
 }
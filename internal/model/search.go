@@ -0,0 +1,12 @@
+package model
+
+// SearchResult is one match from a search across entities. Type is
+// "tenant", "property" or "lease" and says what ID refers to; Title and
+// Subtitle are a short human-readable description.
+type SearchResult struct {
+	Type     string  `json:"type"`
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle"`
+	Rank     float64 `json:"rank"`
+}
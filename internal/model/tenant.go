@@ -3,12 +3,22 @@ package model
 import "time"
 
 type Tenant struct {
-	ID        string    `json:"id"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Address   string    `json:"address"`
-	Email     string    `json:"email"`
-	Phone     string    `json:"phone"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+	Address        Address   `json:"address"`
+	Email          string    `json:"email"`
+	Phone          string    `json:"phone"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TenantFilter narrows a tenant listing. Empty fields match everything;
+// Name matches any part of the full name and PostalCode matches as a prefix.
+type TenantFilter struct {
+	Name       string
+	Email      string
+	City       string
+	PostalCode string
 }
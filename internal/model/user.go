@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// User is an account that can sign in to the API. TenantID links a user
+// with the tenant role to the tenant record they are.
+type User struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Email          string    `json:"email"`
+	Name           string    `json:"name"`
+	Role           string    `json:"role"`
+	TenantID       *string   `json:"tenant_id"`
+	PasswordHash   string    `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Session is issued on login. Token is only ever returned at that point;
+// the database keeps just its hash.
+type Session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      User      `json:"user"`
+}
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// Vendor is a contractor that maintenance work can be assigned to.
+// InsuranceLapsed is derived from InsuranceExpiresOn when the vendor is
+// read; a vendor without an expiry date on file is not flagged.
+type Vendor struct {
+	ID                 string     `json:"id"`
+	OrganizationID     string     `json:"organization_id"`
+	Name               string     `json:"name"`
+	Trade              string     `json:"trade"`
+	ContactName        string     `json:"contact_name"`
+	Email              string     `json:"email"`
+	Phone              string     `json:"phone"`
+	InsuranceExpiresOn *time.Time `json:"insurance_expires_on"`
+	InsuranceLapsed    bool       `json:"insurance_lapsed"`
+	HourlyRate         *float64   `json:"hourly_rate"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// VendorFilter narrows a vendor listing. Zero and nil fields match
+// everything.
+type VendorFilter struct {
+	Trade           string
+	InsuranceLapsed *bool
+}
+
+// VendorJob is a piece of work a vendor did at a property.
+type VendorJob struct {
+	ID                   string    `json:"id"`
+	VendorID             string    `json:"vendor_id"`
+	PropertyID           string    `json:"property_id"`
+	MaintenanceRequestID *string   `json:"maintenance_request_id"`
+	Description          string    `json:"description"`
+	PerformedOn          time.Time `json:"performed_on"`
+	Hours                *float64  `json:"hours"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// VendorInvoice is a bill received from a vendor, optionally for one of
+// their jobs. It is paid once PaidOn is set.
+type VendorInvoice struct {
+	ID        string     `json:"id"`
+	VendorID  string     `json:"vendor_id"`
+	JobID     *string    `json:"job_id"`
+	Number    string     `json:"number"`
+	IssuedOn  time.Time  `json:"issued_on"`
+	DueOn     *time.Time `json:"due_on"`
+	Amount    float64    `json:"amount"`
+	PaidOn    *time.Time `json:"paid_on"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// PropertyVendor is a vendor that has worked at a property, with a summary
+// of the jobs they did there.
+type PropertyVendor struct {
+	Vendor
+	Jobs      int       `json:"jobs"`
+	LastJobOn time.Time `json:"last_job_on"`
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+var (
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+	// postalCodePatterns holds the formats of countries we validate strictly;
+	// any other country only has to look like a postal code.
+	postalCodePatterns = map[string]*regexp.Regexp{
+		"US": regexp.MustCompile(`^[0-9]{5}(-[0-9]{4})?$`),
+		"CA": regexp.MustCompile(`^[A-Z][0-9][A-Z] [0-9][A-Z][0-9]$`),
+		"GB": regexp.MustCompile(`^[A-Z]{1,2}[0-9][A-Z0-9]? [0-9][A-Z]{2}$`),
+		"DE": regexp.MustCompile(`^[0-9]{5}$`),
+		"FR": regexp.MustCompile(`^[0-9]{5}$`),
+		"NL": regexp.MustCompile(`^[0-9]{4} [A-Z]{2}$`),
+		"AU": regexp.MustCompile(`^[0-9]{4}$`),
+	}
+	genericPostalCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,9}$`)
+)
+
+// normalizeAddress trims and collapses whitespace in every part, upper-cases
+// the country and postal code, and puts the space into postal codes that
+// are written with one by convention.
+func normalizeAddress(a model.Address) model.Address {
+	a = model.Address{
+		Street:     collapseSpaces(a.Street),
+		Unit:       collapseSpaces(a.Unit),
+		City:       collapseSpaces(a.City),
+		Region:     collapseSpaces(a.Region),
+		PostalCode: strings.ToUpper(collapseSpaces(a.PostalCode)),
+		Country:    strings.ToUpper(collapseSpaces(a.Country)),
+	}
+
+	switch a.Country {
+	case "CA", "GB", "NL":
+		// The inward part is always the last three (CA, GB) or two (NL)
+		// characters, so the space can be restored unambiguously.
+		code := strings.ReplaceAll(a.PostalCode, " ", "")
+		split := len(code) - 3
+		if a.Country == "NL" {
+			split = len(code) - 2
+		}
+		if split > 0 {
+			a.PostalCode = code[:split] + " " + code[split:]
+		}
+	}
+
+	return a
+}
+
+// validateAddress checks a normalized address. Street, city, postal code and
+// country are required.
+func validateAddress(a model.Address) error {
+	if a.Street == "" {
+		return fmt.Errorf("%w: address street is required", ErrInvalidInput)
+	}
+	if a.City == "" {
+		return fmt.Errorf("%w: address city is required", ErrInvalidInput)
+	}
+	if !countryCodePattern.MatchString(a.Country) {
+		return fmt.Errorf("%w: address country must be a two-letter ISO code", ErrInvalidInput)
+	}
+	if a.PostalCode == "" {
+		return fmt.Errorf("%w: address postal code is required", ErrInvalidInput)
+	}
+
+	pattern, ok := postalCodePatterns[a.Country]
+	if !ok {
+		pattern = genericPostalCodePattern
+	}
+	if !pattern.MatchString(a.PostalCode) {
+		return fmt.Errorf("%w: address postal code %q is not valid for %s", ErrInvalidInput, a.PostalCode, a.Country)
+	}
+
+	if len(a.Street) > 255 || len(a.Unit) > 50 || len(a.City) > 100 || len(a.Region) > 100 {
+		return fmt.Errorf("%w: address is too long", ErrInvalidInput)
+	}
+	return nil
+}
+
+// formatAddress renders an address on one line, skipping empty parts.
+func formatAddress(a model.Address) string {
+	var parts []string
+	for _, part := range []string{a.Street, a.Unit, a.City, a.Region, a.PostalCode, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
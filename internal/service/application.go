@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrInvalidTransition   = errors.New("application cannot move to that status")
+	ErrPropertyUnavailable = errors.New("property is not available for the requested dates")
+)
+
+// applicationTransitions lists the statuses each open status may move to.
+// Approved, rejected and withdrawn applications are final.
+var applicationTransitions = map[string][]string{
+	"submitted":    {"under_review", "rejected", "withdrawn"},
+	"under_review": {"approved", "rejected", "withdrawn"},
+}
+
+// ApplicantDetails are the fields a prospect fills in on an application.
+type ApplicantDetails struct {
+	FirstName        string
+	LastName         string
+	Email            string
+	Phone            string
+	Employer         string
+	JobTitle         string
+	MonthlyIncome    float64
+	DesiredStartDate time.Time
+	DesiredEndDate   time.Time
+}
+
+type ApplicationService struct {
+	applicationStore *store.ApplicationStore
+	propertyStore    *store.PropertyStore
+	tenants          *TenantService
+	leases           *LeaseService
+	tx               *store.TxManager
+	clock            Clock
+}
+
+func NewApplicationService(as *store.ApplicationStore, ps *store.PropertyStore, tenants *TenantService, leases *LeaseService, tx *store.TxManager, clock Clock) *ApplicationService {
+	return &ApplicationService{
+		applicationStore: as,
+		propertyStore:    ps,
+		tenants:          tenants,
+		leases:           leases,
+		tx:               tx,
+		clock:            clock,
+	}
+}
+
+func (s *ApplicationService) List(ctx context.Context, status string) ([]model.Application, error) {
+	if status != "" && !isValidApplicationStatus(status) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidInput, status)
+	}
+	return s.applicationStore.GetAll(ctx, status)
+}
+
+func (s *ApplicationService) GetByID(ctx context.Context, id string) (model.Application, error) {
+	application, err := s.applicationStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Application{}, ErrApplicationNotFound
+	}
+	return application, err
+}
+
+// Submit records a new application for a property that has no lease over
+// the requested dates.
+func (s *ApplicationService) Submit(ctx context.Context, propertyID string, details ApplicantDetails) (model.Application, error) {
+	_, err := s.propertyStore.GetByID(ctx, propertyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Application{}, fmt.Errorf("%w: property not found", ErrInvalidInput)
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	if err := s.validateDetails(details); err != nil {
+		return model.Application{}, err
+	}
+	if err := s.checkAvailable(ctx, propertyID, details); err != nil {
+		return model.Application{}, err
+	}
+
+	application := model.Application{
+		ID:             generateID(),
+		OrganizationID: organizationID(ctx),
+		PropertyID:     propertyID,
+		Status:         "submitted",
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+	applyDetails(&application, details)
+
+	return s.applicationStore.Create(ctx, application)
+}
+
+// Update changes the applicant's details while the application is open.
+func (s *ApplicationService) Update(ctx context.Context, id string, details ApplicantDetails) (model.Application, error) {
+	application, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.Application{}, err
+	}
+	if _, open := applicationTransitions[application.Status]; !open {
+		return model.Application{}, ErrInvalidTransition
+	}
+
+	if err := s.validateDetails(details); err != nil {
+		return model.Application{}, err
+	}
+	if err := s.checkAvailable(ctx, application.PropertyID, details); err != nil {
+		return model.Application{}, err
+	}
+
+	applyDetails(&application, details)
+	application.UpdatedAt = time.Now().UTC()
+
+	return s.applicationStore.Update(ctx, application)
+}
+
+func (s *ApplicationService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	err := s.applicationStore.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrApplicationNotFound
+	}
+	return err
+}
+
+func (s *ApplicationService) Review(ctx context.Context, id string) (model.Application, error) {
+	return s.decide(ctx, id, "under_review", "")
+}
+
+func (s *ApplicationService) Reject(ctx context.Context, id, reason string) (model.Application, error) {
+	return s.decide(ctx, id, "rejected", reason)
+}
+
+func (s *ApplicationService) Withdraw(ctx context.Context, id, reason string) (model.Application, error) {
+	return s.decide(ctx, id, "withdrawn", reason)
+}
+
+// Approve converts the applicant into a tenant and creates an upcoming lease
+// over the requested dates. rentAmount defaults to the property's rent.
+// Everything happens in one transaction, so a lease conflict leaves no
+// stray tenant behind.
+func (s *ApplicationService) Approve(ctx context.Context, id string, rentAmount *float64, deposit float64) (model.Application, error) {
+	application, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.Application{}, err
+	}
+	if !canTransition(applicationTransitions, application.Status, "approved") {
+		return model.Application{}, ErrInvalidTransition
+	}
+	if !dateOnly(application.DesiredStartDate).After(dateOnly(s.clock.Now())) {
+		return model.Application{}, fmt.Errorf("%w: desired start date must be in the future", ErrInvalidInput)
+	}
+
+	property, err := s.propertyStore.GetByID(ctx, application.PropertyID)
+	if err != nil {
+		return model.Application{}, err
+	}
+	rent := property.RentAmount
+	if rentAmount != nil {
+		rent = *rentAmount
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		tenant, err := s.tenants.Create(ctx, application.FirstName, application.LastName, application.Email, application.Phone, model.Address{})
+		if err != nil {
+			return err
+		}
+
+		lease, err := s.leases.Create(ctx, application.PropertyID, tenant.ID, application.DesiredStartDate, application.DesiredEndDate, rent, deposit)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		application.Status = "approved"
+		application.DecidedAt = &now
+		application.TenantID = &tenant.ID
+		application.LeaseID = &lease.ID
+		application.UpdatedAt = now
+
+		application, err = s.applicationStore.Update(ctx, application)
+		return err
+	})
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	return application, nil
+}
+
+func (s *ApplicationService) decide(ctx context.Context, id, status, reason string) (model.Application, error) {
+	application, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.Application{}, err
+	}
+	if !canTransition(applicationTransitions, application.Status, status) {
+		return model.Application{}, ErrInvalidTransition
+	}
+
+	now := time.Now().UTC()
+	application.Status = status
+	application.DecisionReason = reason
+	if status != "under_review" {
+		application.DecidedAt = &now
+	}
+	application.UpdatedAt = now
+
+	return s.applicationStore.Update(ctx, application)
+}
+
+func (s *ApplicationService) checkAvailable(ctx context.Context, propertyID string, details ApplicantDetails) error {
+	err := s.leases.checkOverlap(ctx, propertyID, "", details.DesiredStartDate, details.DesiredEndDate)
+	if errors.Is(err, ErrLeaseOverlap) {
+		return ErrPropertyUnavailable
+	}
+	return err
+}
+
+func (s *ApplicationService) validateDetails(d ApplicantDetails) error {
+	if d.FirstName == "" {
+		return fmt.Errorf("%w: first name is required", ErrInvalidInput)
+	}
+	if d.LastName == "" {
+		return fmt.Errorf("%w: last name is required", ErrInvalidInput)
+	}
+	if d.Email == "" {
+		return fmt.Errorf("%w: email is required", ErrInvalidInput)
+	}
+	if d.MonthlyIncome < 0 {
+		return fmt.Errorf("%w: monthly income cannot be negative", ErrInvalidInput)
+	}
+	if !d.DesiredEndDate.After(d.DesiredStartDate) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+func applyDetails(a *model.Application, d ApplicantDetails) {
+	a.FirstName = d.FirstName
+	a.LastName = d.LastName
+	a.Email = d.Email
+	a.Phone = d.Phone
+	a.Employer = d.Employer
+	a.JobTitle = d.JobTitle
+	a.MonthlyIncome = d.MonthlyIncome
+	a.DesiredStartDate = dateOnly(d.DesiredStartDate)
+	a.DesiredEndDate = dateOnly(d.DesiredEndDate)
+}
+
+// canTransition reports whether transitions lets a record move from one
+// status to another.
+func canTransition(transitions map[string][]string, from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidApplicationStatus(status string) bool {
+	switch status {
+	case "submitted", "under_review", "approved", "rejected", "withdrawn":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrUnauthenticated    = errors.New("authentication required")
+)
+
+// dummyPasswordHash is compared against when a login names an unknown
+// email, so the response takes as long as for a wrong password.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("rntly-dummy-password"), bcrypt.DefaultCost)
+
+type AuthService struct {
+	userStore    *store.UserStore
+	sessionStore *store.SessionStore
+	sessionTTL   time.Duration
+	clock        Clock
+}
+
+func NewAuthService(us *store.UserStore, ss *store.SessionStore, sessionTTL time.Duration, clock Clock) *AuthService {
+	return &AuthService{
+		userStore:    us,
+		sessionStore: ss,
+		sessionTTL:   sessionTTL,
+		clock:        clock,
+	}
+}
+
+// Login checks the credentials and opens a session whose token the client
+// sends back as a bearer token.
+func (s *AuthService) Login(ctx context.Context, email, password string) (model.Session, error) {
+	user, err := s.userStore.GetByEmail(ctx, strings.TrimSpace(email))
+	if errors.Is(err, store.ErrNotFound) {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return model.Session{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return model.Session{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return model.Session{}, ErrInvalidCredentials
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		return model.Session{}, err
+	}
+
+	now := s.clock.Now().UTC()
+	session := model.Session{
+		Token:     token,
+		ExpiresAt: now.Add(s.sessionTTL),
+		User:      user,
+	}
+
+	if err := s.sessionStore.Create(ctx, hashToken(token), user.ID, now, session.ExpiresAt); err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+// Logout ends the session identified by token. Ending a session that does
+// not exist is not an error.
+func (s *AuthService) Logout(ctx context.Context, token string) error {
+	return s.sessionStore.Delete(ctx, hashToken(token))
+}
+
+// Authenticate returns the user a live session token belongs to.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (model.User, error) {
+	if token == "" {
+		return model.User{}, ErrUnauthenticated
+	}
+
+	user, err := s.sessionStore.GetUser(ctx, hashToken(token), s.clock.Now().UTC())
+	if errors.Is(err, store.ErrNotFound) {
+		return model.User{}, ErrUnauthenticated
+	}
+	return user, err
+}
+
+// PurgeExpiredSessions deletes sessions that can no longer be used.
+func (s *AuthService) PurgeExpiredSessions(ctx context.Context) error {
+	return s.sessionStore.DeleteExpired(ctx, s.clock.Now().UTC())
+}
+
+func newSessionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
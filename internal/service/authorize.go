@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Lacsw/rntly/internal/auth"
+)
+
+var ErrForbidden = errors.New("not allowed to perform this action")
+
+// authorize returns ErrForbidden unless the signed-in user in ctx has the
+// permission. Which records they see is decided by the stores' scoping.
+func authorize(ctx context.Context, perm auth.Permission) error {
+	if !auth.Allowed(ctx, perm) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// organizationID returns the organization of the signed-in user in ctx,
+// which the records they create belong to.
+func organizationID(ctx context.Context) string {
+	return auth.ScopeFrom(ctx).OrganizationID
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrBuildingNotFound = errors.New("building not found")
+	ErrBuildingNotEmpty = errors.New("building still has units")
+)
+
+type BuildingService struct {
+	buildingStore *store.BuildingStore
+	propertyStore *store.PropertyStore
+	ownerStore    *store.OwnerStore
+}
+
+func NewBuildingService(bs *store.BuildingStore, ps *store.PropertyStore, os *store.OwnerStore) *BuildingService {
+	return &BuildingService{
+		buildingStore: bs,
+		propertyStore: ps,
+		ownerStore:    os,
+	}
+}
+
+func (s *BuildingService) List(ctx context.Context) ([]model.Building, error) {
+	return s.buildingStore.GetAll(ctx)
+}
+
+func (s *BuildingService) GetByID(ctx context.Context, id string) (model.Building, error) {
+	building, err := s.buildingStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Building{}, ErrBuildingNotFound
+	}
+	return building, err
+}
+
+// Units returns the building's units with their occupancy as of the given
+// date, together with a summary across all of them.
+func (s *BuildingService) Units(ctx context.Context, id string, asOf time.Time) (model.BuildingUnits, error) {
+	building, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.BuildingUnits{}, err
+	}
+
+	units, err := s.propertyStore.GetByBuildingID(ctx, id, asOf)
+	if err != nil {
+		return model.BuildingUnits{}, err
+	}
+	if units == nil {
+		units = []model.Property{}
+	}
+
+	summary := model.OccupancySummary{TotalUnits: len(units)}
+	for _, u := range units {
+		if u.Status == "occupied" {
+			summary.OccupiedUnits++
+			summary.RentRoll += u.RentAmount
+		}
+	}
+	summary.VacantUnits = summary.TotalUnits - summary.OccupiedUnits
+	if summary.TotalUnits > 0 {
+		summary.OccupancyRate = roundCents(float64(summary.OccupiedUnits) / float64(summary.TotalUnits))
+	}
+	summary.RentRoll = roundCents(summary.RentRoll)
+
+	return model.BuildingUnits{
+		Building: building,
+		AsOf:     dateOnly(asOf),
+		Summary:  summary,
+		Units:    units,
+	}, nil
+}
+
+func (s *BuildingService) Create(ctx context.Context, name string, address model.Address, ownerID *string, amenities []string) (model.Building, error) {
+	address = normalizeAddress(address)
+	if err := s.validateInput(name, address); err != nil {
+		return model.Building{}, err
+	}
+	if err := s.checkOwner(ctx, ownerID); err != nil {
+		return model.Building{}, err
+	}
+
+	building := model.Building{
+		ID:             generateID(),
+		OrganizationID: organizationID(ctx),
+		Name:           name,
+		Address:        address,
+		OwnerID:        ownerID,
+		Amenities:      normalizeAmenities(amenities),
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+
+	return s.buildingStore.Create(ctx, building)
+}
+
+func (s *BuildingService) Update(ctx context.Context, id, name string, address model.Address, ownerID *string, amenities []string) (model.Building, error) {
+	existing, err := s.buildingStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Building{}, ErrBuildingNotFound
+	}
+	if err != nil {
+		return model.Building{}, err
+	}
+
+	address = normalizeAddress(address)
+	if err := s.validateInput(name, address); err != nil {
+		return model.Building{}, err
+	}
+	if err := s.checkOwner(ctx, ownerID); err != nil {
+		return model.Building{}, err
+	}
+
+	existing.Name = name
+	existing.Address = address
+	existing.OwnerID = ownerID
+	existing.Amenities = normalizeAmenities(amenities)
+	existing.UpdatedAt = time.Now().UTC()
+
+	return s.buildingStore.Update(ctx, existing)
+}
+
+// Delete removes a building. Its units must be deleted or moved out first.
+func (s *BuildingService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	units, err := s.propertyStore.GetByBuildingID(ctx, id, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if len(units) > 0 {
+		return ErrBuildingNotEmpty
+	}
+
+	err = s.buildingStore.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrBuildingNotFound
+	}
+	return err
+}
+
+func (s *BuildingService) validateInput(name string, address model.Address) error {
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	return validateAddress(address)
+}
+
+// checkOwner reports an invalid input if ownerID is set but names no owner
+// the caller can see.
+func (s *BuildingService) checkOwner(ctx context.Context, ownerID *string) error {
+	if ownerID == nil {
+		return nil
+	}
+	_, err := s.ownerStore.GetByID(ctx, *ownerID)
+	if errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("%w: owner not found", ErrInvalidInput)
+	}
+	return err
+}
+
+// normalizeAmenities lower-cases and trims each amenity and drops blanks and
+// repeats, so amenities compare equal however they were typed.
+func normalizeAmenities(amenities []string) []string {
+	seen := make(map[string]bool, len(amenities))
+	normalized := []string{}
+	for _, a := range amenities {
+		a = strings.ToLower(collapseSpaces(a))
+		if a == "" || seen[a] {
+			continue
+		}
+		seen[a] = true
+		normalized = append(normalized, a)
+	}
+	return normalized
+}
@@ -0,0 +1,15 @@
+package service
+
+import "time"
+
+// Clock supplies the current time to date-driven logic so it can be run
+// against a fixed date instead of the wall clock.
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrDepositNotFound   = errors.New("deposit settlement not found")
+	ErrDeductionNotFound = errors.New("deduction not found")
+	ErrDepositSettled    = errors.New("deposit is already settled")
+)
+
+type DepositService struct {
+	depositStore *store.DepositStore
+	leaseStore   *store.LeaseStore
+	payments     *PaymentService
+	tx           *store.TxManager
+	returnDays   int
+}
+
+// NewDepositService creates the service. returnDays is the statutory window,
+// counted from the lease end date, within which a deposit must be returned.
+func NewDepositService(ds *store.DepositStore, ls *store.LeaseStore, payments *PaymentService, tx *store.TxManager, returnDays int) *DepositService {
+	return &DepositService{
+		depositStore: ds,
+		leaseStore:   ls,
+		payments:     payments,
+		tx:           tx,
+		returnDays:   returnDays,
+	}
+}
+
+func (s *DepositService) List(ctx context.Context, status string) ([]model.DepositSettlement, error) {
+	if status != "" && !isValidDepositStatus(status) {
+		return nil, fmt.Errorf("%w: status must be 'pending' or 'settled'", ErrInvalidInput)
+	}
+
+	settlements, err := s.depositStore.GetAll(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range settlements {
+		if err := s.fill(ctx, &settlements[i]); err != nil {
+			return nil, err
+		}
+	}
+	return settlements, nil
+}
+
+// GetByLeaseID returns the settlement statement for a lease.
+func (s *DepositService) GetByLeaseID(ctx context.Context, leaseID string) (model.DepositSettlement, error) {
+	settlement, err := s.getByLeaseID(ctx, leaseID)
+	if err != nil {
+		return model.DepositSettlement{}, err
+	}
+	if err := s.fill(ctx, &settlement); err != nil {
+		return model.DepositSettlement{}, err
+	}
+	return settlement, nil
+}
+
+// Open starts the settlement of an ended lease. Any balance still owing on
+// the lease ledger is added as an unpaid rent deduction. Opening a lease
+// that already has a settlement, or whose deposit was carried over to a
+// renewal, is a no-op.
+func (s *DepositService) Open(ctx context.Context, lease model.Lease) error {
+	_, err := s.leaseStore.GetByPreviousLeaseID(ctx, lease.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	settlement := model.DepositSettlement{
+		ID:            generateID(),
+		LeaseID:       lease.ID,
+		DepositAmount: lease.Deposit,
+		Status:        "pending",
+		Deadline:      dateOnly(lease.EndDate).AddDate(0, 0, s.returnDays),
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		created, err := s.depositStore.Create(ctx, settlement)
+		if err != nil || !created {
+			return err
+		}
+
+		ledger, err := s.payments.Ledger(ctx, lease.ID, lease.EndDate)
+		if err != nil {
+			return err
+		}
+		if ledger.Balance > 0 {
+			_, err = s.depositStore.CreateDeduction(ctx, model.DepositDeduction{
+				ID:           generateID(),
+				SettlementID: settlement.ID,
+				Category:     "unpaid_rent",
+				Description:  "Outstanding ledger balance at lease end",
+				Amount:       ledger.Balance,
+				CreatedAt:    time.Now().UTC(),
+			})
+		}
+		return err
+	})
+}
+
+func (s *DepositService) AddDeduction(ctx context.Context, leaseID, category, description string, amount float64) (model.DepositDeduction, error) {
+	settlement, err := s.getByLeaseID(ctx, leaseID)
+	if err != nil {
+		return model.DepositDeduction{}, err
+	}
+	if settlement.Status == "settled" {
+		return model.DepositDeduction{}, ErrDepositSettled
+	}
+
+	if !isValidDeductionCategory(category) {
+		return model.DepositDeduction{}, fmt.Errorf("%w: category must be 'damage', 'unpaid_rent', 'cleaning' or 'other'", ErrInvalidInput)
+	}
+	if amount <= 0 {
+		return model.DepositDeduction{}, fmt.Errorf("%w: amount must be positive", ErrInvalidInput)
+	}
+
+	deduction := model.DepositDeduction{
+		ID:           generateID(),
+		SettlementID: settlement.ID,
+		Category:     category,
+		Description:  description,
+		Amount:       roundCents(amount),
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	return s.depositStore.CreateDeduction(ctx, deduction)
+}
+
+func (s *DepositService) DeleteDeduction(ctx context.Context, leaseID, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	settlement, err := s.getByLeaseID(ctx, leaseID)
+	if err != nil {
+		return err
+	}
+	if settlement.Status == "settled" {
+		return ErrDepositSettled
+	}
+
+	err = s.depositStore.DeleteDeduction(ctx, settlement.ID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrDeductionNotFound
+	}
+	return err
+}
+
+// Settle records that the refund (or the demand for the amount owed) has
+// been issued. The statement is frozen afterwards.
+func (s *DepositService) Settle(ctx context.Context, leaseID string, settledAt time.Time) (model.DepositSettlement, error) {
+	settlement, err := s.getByLeaseID(ctx, leaseID)
+	if err != nil {
+		return model.DepositSettlement{}, err
+	}
+	if settlement.Status == "settled" {
+		return model.DepositSettlement{}, ErrDepositSettled
+	}
+
+	settledAt = dateOnly(settledAt)
+	settlement.Status = "settled"
+	settlement.SettledAt = &settledAt
+	settlement.UpdatedAt = time.Now().UTC()
+
+	updated, err := s.depositStore.Update(ctx, settlement)
+	if err != nil {
+		return model.DepositSettlement{}, err
+	}
+	if err := s.fill(ctx, &updated); err != nil {
+		return model.DepositSettlement{}, err
+	}
+	return updated, nil
+}
+
+func (s *DepositService) getByLeaseID(ctx context.Context, leaseID string) (model.DepositSettlement, error) {
+	_, err := s.leaseStore.GetByID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.DepositSettlement{}, ErrLeaseNotFound
+	}
+	if err != nil {
+		return model.DepositSettlement{}, err
+	}
+
+	settlement, err := s.depositStore.GetByLeaseID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.DepositSettlement{}, ErrDepositNotFound
+	}
+	return settlement, err
+}
+
+// fill loads the deductions of a settlement and computes its totals and
+// deadline status.
+func (s *DepositService) fill(ctx context.Context, settlement *model.DepositSettlement) error {
+	deductions, err := s.depositStore.GetDeductions(ctx, settlement.ID)
+	if err != nil {
+		return err
+	}
+
+	settlement.Deductions = []model.DepositDeduction{}
+	settlement.TotalDeductions = 0
+	for _, d := range deductions {
+		settlement.Deductions = append(settlement.Deductions, d)
+		settlement.TotalDeductions = roundCents(settlement.TotalDeductions + d.Amount)
+	}
+
+	remaining := roundCents(settlement.DepositAmount - settlement.TotalDeductions)
+	settlement.RefundAmount = 0
+	settlement.AmountOwed = 0
+	if remaining >= 0 {
+		settlement.RefundAmount = remaining
+	} else {
+		settlement.AmountOwed = -remaining
+	}
+
+	if settlement.Status == "pending" {
+		settlement.DaysUntilDeadline = daysBetween(time.Now().UTC(), settlement.Deadline)
+		settlement.Overdue = settlement.DaysUntilDeadline < 0
+	}
+	return nil
+}
+
+func isValidDepositStatus(status string) bool {
+	return status == "pending" || status == "settled"
+}
+
+func isValidDeductionCategory(category string) bool {
+	switch category {
+	case "damage", "unpaid_rent", "cleaning", "other":
+		return true
+	}
+	return false
+}
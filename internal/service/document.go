@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var ErrDocumentNotFound = errors.New("document not found")
+
+type DocumentService struct {
+	documentStore *store.DocumentStore
+	leaseStore    *store.LeaseStore
+}
+
+func NewDocumentService(ds *store.DocumentStore, ls *store.LeaseStore) *DocumentService {
+	return &DocumentService{
+		documentStore: ds,
+		leaseStore:    ls,
+	}
+}
+
+func (s *DocumentService) GetByLeaseID(ctx context.Context, leaseID string) ([]model.Document, error) {
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return nil, err
+	}
+	return s.documentStore.GetByLeaseIDs(ctx, []string{leaseID})
+}
+
+// Create attaches a link to a document to the lease. The URL must be an
+// absolute http or https address.
+func (s *DocumentService) Create(ctx context.Context, leaseID, title, category, link string) (model.Document, error) {
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return model.Document{}, err
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return model.Document{}, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+	if !isValidDocumentCategory(category) {
+		return model.Document{}, fmt.Errorf("%w: category must be 'agreement', 'addendum', 'notice', 'inspection' or 'other'", ErrInvalidInput)
+	}
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return model.Document{}, fmt.Errorf("%w: url must be an absolute http or https address", ErrInvalidInput)
+	}
+
+	document := model.Document{
+		ID:        generateID(),
+		LeaseID:   leaseID,
+		Title:     title,
+		Category:  category,
+		URL:       link,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	return s.documentStore.Create(ctx, document)
+}
+
+func (s *DocumentService) Delete(ctx context.Context, leaseID, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return err
+	}
+
+	err := s.documentStore.Delete(ctx, leaseID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrDocumentNotFound
+	}
+	return err
+}
+
+func (s *DocumentService) checkLease(ctx context.Context, leaseID string) error {
+	_, err := s.leaseStore.GetByID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrLeaseNotFound
+	}
+	return err
+}
+
+func isValidDocumentCategory(category string) bool {
+	switch category {
+	case "agreement", "addendum", "notice", "inspection", "other":
+		return true
+	}
+	return false
+}
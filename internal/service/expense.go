@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var ErrExpenseNotFound = errors.New("expense not found")
+
+type ExpenseService struct {
+	expenseStore  *store.ExpenseStore
+	propertyStore *store.PropertyStore
+}
+
+func NewExpenseService(es *store.ExpenseStore, ps *store.PropertyStore) *ExpenseService {
+	return &ExpenseService{
+		expenseStore:  es,
+		propertyStore: ps,
+	}
+}
+
+func (s *ExpenseService) GetByPropertyID(ctx context.Context, propertyID string) ([]model.Expense, error) {
+	if err := s.checkProperty(ctx, propertyID); err != nil {
+		return nil, err
+	}
+	return s.expenseStore.GetByPropertyID(ctx, propertyID)
+}
+
+func (s *ExpenseService) Create(ctx context.Context, propertyID string, incurredOn time.Time, category, description string, amount float64) (model.Expense, error) {
+	if err := s.checkProperty(ctx, propertyID); err != nil {
+		return model.Expense{}, err
+	}
+
+	if amount <= 0 {
+		return model.Expense{}, fmt.Errorf("%w: amount must be positive", ErrInvalidInput)
+	}
+	if !isValidExpenseCategory(category) {
+		return model.Expense{}, fmt.Errorf("%w: category must be 'repairs', 'utilities', 'insurance', 'taxes', 'cleaning' or 'other'", ErrInvalidInput)
+	}
+
+	expense := model.Expense{
+		ID:          generateID(),
+		PropertyID:  propertyID,
+		IncurredOn:  dateOnly(incurredOn),
+		Category:    category,
+		Description: strings.TrimSpace(description),
+		Amount:      roundCents(amount),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	return s.expenseStore.Create(ctx, expense)
+}
+
+func (s *ExpenseService) Delete(ctx context.Context, propertyID, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if err := s.checkProperty(ctx, propertyID); err != nil {
+		return err
+	}
+
+	err := s.expenseStore.Delete(ctx, propertyID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrExpenseNotFound
+	}
+	return err
+}
+
+func (s *ExpenseService) checkProperty(ctx context.Context, propertyID string) error {
+	_, err := s.propertyStore.GetByID(ctx, propertyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrPropertyNotFound
+	}
+	return err
+}
+
+func isValidExpenseCategory(category string) bool {
+	switch category {
+	case "repairs", "utilities", "insurance", "taxes", "cleaning", "other":
+		return true
+	}
+	return false
+}
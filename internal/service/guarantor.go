@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrGuarantorNotFound      = errors.New("guarantor not found")
+	ErrGuarantorAlreadyLinked = errors.New("guarantor is already linked to this lease")
+)
+
+type GuarantorService struct {
+	guarantorStore *store.GuarantorStore
+	leaseStore     *store.LeaseStore
+}
+
+func NewGuarantorService(gs *store.GuarantorStore, ls *store.LeaseStore) *GuarantorService {
+	return &GuarantorService{
+		guarantorStore: gs,
+		leaseStore:     ls,
+	}
+}
+
+func (s *GuarantorService) List(ctx context.Context) ([]model.Guarantor, error) {
+	return s.guarantorStore.GetAll(ctx)
+}
+
+func (s *GuarantorService) GetByID(ctx context.Context, id string) (model.Guarantor, error) {
+	guarantor, err := s.guarantorStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Guarantor{}, ErrGuarantorNotFound
+	}
+	return guarantor, err
+}
+
+func (s *GuarantorService) GetByLeaseID(ctx context.Context, leaseID string) ([]model.Guarantor, error) {
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return nil, err
+	}
+	return s.guarantorStore.GetByLeaseID(ctx, leaseID)
+}
+
+func (s *GuarantorService) Create(ctx context.Context, firstName, lastName, email, phone, address string, annualIncome, liabilityCap *float64) (model.Guarantor, error) {
+	if err := s.validateInput(firstName, lastName, email, phone, annualIncome, liabilityCap); err != nil {
+		return model.Guarantor{}, err
+	}
+
+	guarantor := model.Guarantor{
+		ID:             generateID(),
+		OrganizationID: organizationID(ctx),
+		FirstName:      firstName,
+		LastName:       lastName,
+		Email:          email,
+		Phone:          phone,
+		Address:        address,
+		AnnualIncome:   annualIncome,
+		LiabilityCap:   liabilityCap,
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+
+	return s.guarantorStore.Create(ctx, guarantor)
+}
+
+func (s *GuarantorService) Update(ctx context.Context, id, firstName, lastName, email, phone, address string, annualIncome, liabilityCap *float64) (model.Guarantor, error) {
+	existing, err := s.guarantorStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Guarantor{}, ErrGuarantorNotFound
+	}
+	if err != nil {
+		return model.Guarantor{}, err
+	}
+
+	if err := s.validateInput(firstName, lastName, email, phone, annualIncome, liabilityCap); err != nil {
+		return model.Guarantor{}, err
+	}
+
+	existing.FirstName = firstName
+	existing.LastName = lastName
+	existing.Email = email
+	existing.Phone = phone
+	existing.Address = address
+	existing.AnnualIncome = annualIncome
+	existing.LiabilityCap = liabilityCap
+	existing.UpdatedAt = time.Now().UTC()
+
+	return s.guarantorStore.Update(ctx, existing)
+}
+
+func (s *GuarantorService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	err := s.guarantorStore.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrGuarantorNotFound
+	}
+	return err
+}
+
+func (s *GuarantorService) Link(ctx context.Context, leaseID, guarantorID string) error {
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return err
+	}
+
+	_, err := s.guarantorStore.GetByID(ctx, guarantorID)
+	if errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("%w: guarantor not found", ErrInvalidInput)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.guarantorStore.Link(ctx, leaseID, guarantorID)
+	if errors.Is(err, store.ErrDuplicate) {
+		return ErrGuarantorAlreadyLinked
+	}
+	return err
+}
+
+func (s *GuarantorService) Unlink(ctx context.Context, leaseID, guarantorID string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return err
+	}
+
+	err := s.guarantorStore.Unlink(ctx, leaseID, guarantorID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrGuarantorNotFound
+	}
+	return err
+}
+
+func (s *GuarantorService) checkLease(ctx context.Context, leaseID string) error {
+	_, err := s.leaseStore.GetByID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrLeaseNotFound
+	}
+	return err
+}
+
+func (s *GuarantorService) validateInput(firstName, lastName, email, phone string, annualIncome, liabilityCap *float64) error {
+	if firstName == "" {
+		return fmt.Errorf("%w: first name is required", ErrInvalidInput)
+	}
+	if lastName == "" {
+		return fmt.Errorf("%w: last name is required", ErrInvalidInput)
+	}
+	if email == "" && phone == "" {
+		return fmt.Errorf("%w: email or phone is required", ErrInvalidInput)
+	}
+	if annualIncome != nil && *annualIncome < 0 {
+		return fmt.Errorf("%w: annual income cannot be negative", ErrInvalidInput)
+	}
+	if liabilityCap != nil && *liabilityCap <= 0 {
+		return fmt.Errorf("%w: liability cap must be positive", ErrInvalidInput)
+	}
+	return nil
+}
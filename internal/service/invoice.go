@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+type InvoiceService struct {
+	invoiceStore *store.InvoiceStore
+	leaseStore   *store.LeaseStore
+}
+
+func NewInvoiceService(is *store.InvoiceStore, ls *store.LeaseStore) *InvoiceService {
+	return &InvoiceService{
+		invoiceStore: is,
+		leaseStore:   ls,
+	}
+}
+
+func (s *InvoiceService) List(ctx context.Context, status string) ([]model.Invoice, error) {
+	if status != "" && !isValidInvoiceStatus(status) {
+		return nil, fmt.Errorf("%w: status must be 'unpaid', 'partial' or 'paid'", ErrInvalidInput)
+	}
+	return s.invoiceStore.GetAll(ctx, status)
+}
+
+func (s *InvoiceService) GetByLeaseID(ctx context.Context, leaseID string) ([]model.Invoice, error) {
+	_, err := s.leaseStore.GetByID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrLeaseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.invoiceStore.GetByLeaseID(ctx, leaseID)
+}
+
+// GenerateDue creates an invoice for every billing period of every active
+// lease whose due date has arrived by today. Existing invoices are left
+// untouched, so running it repeatedly never double-bills.
+func (s *InvoiceService) GenerateDue(ctx context.Context, today time.Time) error {
+	leases, err := s.leaseStore.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	created := 0
+	for _, lease := range leases {
+		if lease.Status != "active" {
+			continue
+		}
+
+		for _, p := range rentPeriods(lease, today) {
+			invoice := model.Invoice{
+				ID:          generateID(),
+				LeaseID:     lease.ID,
+				PeriodStart: p.Start,
+				PeriodEnd:   p.End,
+				DueDate:     p.Start,
+				Amount:      p.Amount,
+				CreatedAt:   time.Now().UTC(),
+			}
+
+			ok, err := s.invoiceStore.Create(ctx, invoice)
+			if err != nil {
+				return fmt.Errorf("lease %s: %w", lease.ID, err)
+			}
+			if ok {
+				created++
+			}
+		}
+	}
+
+	if created > 0 {
+		log.Printf("generated %d rent invoices", created)
+	}
+	return nil
+}
+
+func isValidInvoiceStatus(status string) bool {
+	return status == "unpaid" || status == "partial" || status == "paid"
+}
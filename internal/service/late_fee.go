@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrLateFeePolicyNotFound = errors.New("late fee policy not found")
+)
+
+type LateFeeService struct {
+	policyStore   *store.LateFeePolicyStore
+	chargeStore   *store.ChargeStore
+	invoiceStore  *store.InvoiceStore
+	leaseStore    *store.LeaseStore
+	propertyStore *store.PropertyStore
+}
+
+func NewLateFeeService(pols *store.LateFeePolicyStore, cs *store.ChargeStore, is *store.InvoiceStore, ls *store.LeaseStore, ps *store.PropertyStore) *LateFeeService {
+	return &LateFeeService{
+		policyStore:   pols,
+		chargeStore:   cs,
+		invoiceStore:  is,
+		leaseStore:    ls,
+		propertyStore: ps,
+	}
+}
+
+func (s *LateFeeService) GetPropertyPolicy(ctx context.Context, propertyID string) (model.LateFeePolicy, error) {
+	if err := s.checkProperty(ctx, propertyID); err != nil {
+		return model.LateFeePolicy{}, err
+	}
+
+	policy, err := s.policyStore.GetByPropertyID(ctx, propertyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.LateFeePolicy{}, ErrLateFeePolicyNotFound
+	}
+	return policy, err
+}
+
+func (s *LateFeeService) GetLeasePolicy(ctx context.Context, leaseID string) (model.LateFeePolicy, error) {
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return model.LateFeePolicy{}, err
+	}
+
+	policy, err := s.policyStore.GetByLeaseID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.LateFeePolicy{}, ErrLateFeePolicyNotFound
+	}
+	return policy, err
+}
+
+func (s *LateFeeService) SetPropertyPolicy(ctx context.Context, propertyID string, graceDays int, flatFee, percent, dailyFee float64, maxFee *float64) (model.LateFeePolicy, error) {
+	if err := s.checkProperty(ctx, propertyID); err != nil {
+		return model.LateFeePolicy{}, err
+	}
+
+	policy := model.LateFeePolicy{PropertyID: &propertyID}
+	return s.save(ctx, policy, graceDays, flatFee, percent, dailyFee, maxFee)
+}
+
+func (s *LateFeeService) SetLeasePolicy(ctx context.Context, leaseID string, graceDays int, flatFee, percent, dailyFee float64, maxFee *float64) (model.LateFeePolicy, error) {
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return model.LateFeePolicy{}, err
+	}
+
+	policy := model.LateFeePolicy{LeaseID: &leaseID}
+	return s.save(ctx, policy, graceDays, flatFee, percent, dailyFee, maxFee)
+}
+
+func (s *LateFeeService) DeletePropertyPolicy(ctx context.Context, propertyID string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if err := s.checkProperty(ctx, propertyID); err != nil {
+		return err
+	}
+
+	err := s.policyStore.DeleteByPropertyID(ctx, propertyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrLateFeePolicyNotFound
+	}
+	return err
+}
+
+func (s *LateFeeService) DeleteLeasePolicy(ctx context.Context, leaseID string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if err := s.checkLease(ctx, leaseID); err != nil {
+		return err
+	}
+
+	err := s.policyStore.DeleteByLeaseID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrLateFeePolicyNotFound
+	}
+	return err
+}
+
+func (s *LateFeeService) checkProperty(ctx context.Context, propertyID string) error {
+	_, err := s.propertyStore.GetByID(ctx, propertyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrPropertyNotFound
+	}
+	return err
+}
+
+func (s *LateFeeService) checkLease(ctx context.Context, leaseID string) error {
+	_, err := s.leaseStore.GetByID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrLeaseNotFound
+	}
+	return err
+}
+
+func (s *LateFeeService) save(ctx context.Context, policy model.LateFeePolicy, graceDays int, flatFee, percent, dailyFee float64, maxFee *float64) (model.LateFeePolicy, error) {
+	if graceDays < 0 {
+		return model.LateFeePolicy{}, fmt.Errorf("%w: grace days cannot be negative", ErrInvalidInput)
+	}
+	if flatFee < 0 || dailyFee < 0 {
+		return model.LateFeePolicy{}, fmt.Errorf("%w: fees cannot be negative", ErrInvalidInput)
+	}
+	if percent < 0 || percent > 100 {
+		return model.LateFeePolicy{}, fmt.Errorf("%w: percent must be between 0 and 100", ErrInvalidInput)
+	}
+	if maxFee != nil && *maxFee < 0 {
+		return model.LateFeePolicy{}, fmt.Errorf("%w: max fee cannot be negative", ErrInvalidInput)
+	}
+
+	policy.ID = generateID()
+	policy.GraceDays = graceDays
+	policy.FlatFee = flatFee
+	policy.Percent = percent
+	policy.DailyFee = dailyFee
+	policy.MaxFee = maxFee
+	policy.CreatedAt = time.Now().UTC()
+	policy.UpdatedAt = time.Now().UTC()
+
+	return s.policyStore.Save(ctx, policy)
+}
+
+// ApplyLateFees charges late fees on every invoice that is still not fully
+// paid once its grace period has passed. The one-off fee (flat plus
+// percentage of rent) and each day of accrual are separate ledger lines,
+// and existing lines are never written twice, so the job can run any
+// number of times a day.
+func (s *LateFeeService) ApplyLateFees(ctx context.Context, today time.Time) error {
+	invoices, err := s.invoiceStore.GetAll(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	today = dateOnly(today)
+	leases := make(map[string]model.Lease)
+	policies := make(map[string]*model.LateFeePolicy)
+	created := 0
+
+	for _, invoice := range invoices {
+		if invoice.Status == "paid" {
+			continue
+		}
+
+		lease, ok := leases[invoice.LeaseID]
+		if !ok {
+			lease, err = s.leaseStore.GetByID(ctx, invoice.LeaseID)
+			if err != nil {
+				return fmt.Errorf("lease %s: %w", invoice.LeaseID, err)
+			}
+			leases[lease.ID] = lease
+		}
+
+		policy, ok := policies[lease.ID]
+		if !ok {
+			policy, err = s.policyFor(ctx, lease)
+			if err != nil {
+				return fmt.Errorf("lease %s: %w", lease.ID, err)
+			}
+			policies[lease.ID] = policy
+		}
+		if policy == nil {
+			continue
+		}
+
+		n, err := s.applyToInvoice(ctx, lease, invoice, *policy, today)
+		if err != nil {
+			return fmt.Errorf("invoice %s: %w", invoice.ID, err)
+		}
+		created += n
+	}
+
+	if created > 0 {
+		log.Printf("applied %d late fee charges", created)
+	}
+	return nil
+}
+
+func (s *LateFeeService) applyToInvoice(ctx context.Context, lease model.Lease, invoice model.Invoice, policy model.LateFeePolicy, today time.Time) (int, error) {
+	firstLateDay := dateOnly(invoice.DueDate).AddDate(0, 0, policy.GraceDays+1)
+	if today.Before(firstLateDay) {
+		return 0, nil
+	}
+
+	existing, err := s.chargeStore.GetByInvoiceID(ctx, invoice.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	charged := make(map[string]bool)
+	total := 0.0
+	for _, c := range existing {
+		charged[c.Type+c.ChargeDate.Format("2006-01-02")] = true
+		total += c.Amount
+	}
+
+	due := invoice.DueDate.Format("2006-01-02")
+	created := 0
+	add := func(chargeType, description string, amount float64, date time.Time) error {
+		if charged[chargeType+date.Format("2006-01-02")] {
+			return nil
+		}
+		if policy.MaxFee != nil && amount > *policy.MaxFee-total {
+			amount = *policy.MaxFee - total
+		}
+		amount = roundCents(amount)
+		if amount <= 0 {
+			return nil
+		}
+
+		ok, err := s.chargeStore.Create(ctx, model.LeaseCharge{
+			ID:          generateID(),
+			LeaseID:     lease.ID,
+			InvoiceID:   &invoice.ID,
+			Type:        chargeType,
+			Description: description,
+			Amount:      amount,
+			ChargeDate:  date,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		if ok {
+			total += amount
+			created++
+		}
+		return nil
+	}
+
+	oneOff := policy.FlatFee + lease.RentAmount*policy.Percent/100
+	if err := add("late_fee", fmt.Sprintf("Late fee for rent due %s", due), oneOff, firstLateDay); err != nil {
+		return created, err
+	}
+
+	if policy.DailyFee > 0 {
+		for day := firstLateDay; !day.After(today); day = day.AddDate(0, 0, 1) {
+			if err := add("late_fee_daily", fmt.Sprintf("Daily late fee for rent due %s", due), policy.DailyFee, day); err != nil {
+				return created, err
+			}
+		}
+	}
+
+	return created, nil
+}
+
+// policyFor resolves the policy that applies to a lease: its own if set,
+// otherwise its property's. It returns nil when neither exists.
+func (s *LateFeeService) policyFor(ctx context.Context, lease model.Lease) (*model.LateFeePolicy, error) {
+	policy, err := s.policyStore.GetByLeaseID(ctx, lease.ID)
+	if err == nil {
+		return &policy, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	policy, err = s.policyStore.GetByPropertyID(ctx, lease.PropertyID)
+	if err == nil {
+		return &policy, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	return nil, nil
+}
@@ -6,35 +6,79 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Lacsw/rntly/internal/auth"
 	"github.com/Lacsw/rntly/internal/model"
 	"github.com/Lacsw/rntly/internal/store"
 )
 
 var (
-	ErrLeaseNotFound     = errors.New("lease not found")
-	ErrPropertyNotVacant = errors.New("property is not vacant")
-	ErrInvalidDateRange  = errors.New("end date must be after start date")
+	ErrLeaseNotFound    = errors.New("lease not found")
+	ErrLeaseOverlap     = errors.New("lease dates overlap an existing lease")
+	ErrInvalidDateRange = errors.New("end date must be after start date")
 )
 
+// LeaseConflictError reports the existing lease a new date range collides
+// with. It matches ErrLeaseOverlap with errors.Is.
+type LeaseConflictError struct {
+	LeaseID string
+}
+
+func (e *LeaseConflictError) Error() string {
+	return fmt.Sprintf("lease dates overlap lease %s", e.LeaseID)
+}
+
+func (e *LeaseConflictError) Unwrap() error {
+	return ErrLeaseOverlap
+}
+
 type LeaseService struct {
 	leaseStore    *store.LeaseStore
 	propertyStore *store.PropertyStore
 	tenantStore   *store.TenantStore
+	chargeStore   *store.ChargeStore
+	invoiceStore  *store.InvoiceStore
+	deposits      *DepositService
+	tx            *store.TxManager
+	clock         Clock
 }
 
-func NewLeaseService(ls *store.LeaseStore, ps *store.PropertyStore, ts *store.TenantStore) *LeaseService {
+func NewLeaseService(ls *store.LeaseStore, ps *store.PropertyStore, ts *store.TenantStore, cs *store.ChargeStore, is *store.InvoiceStore, ds *DepositService, tx *store.TxManager, clock Clock) *LeaseService {
 	return &LeaseService{
 		leaseStore:    ls,
 		propertyStore: ps,
 		tenantStore:   ts,
+		chargeStore:   cs,
+		invoiceStore:  is,
+		deposits:      ds,
+		tx:            tx,
+		clock:         clock,
 	}
 }
 
-func (s *LeaseService) List(ctx context.Context) ([]model.Lease, error) {
-	return s.leaseStore.GetAll(ctx)
+// List returns one page of the leases matching the filter.
+func (s *LeaseService) List(ctx context.Context, filter model.LeaseFilter, page model.PageRequest) (model.Page[model.Lease], error) {
+	if err := authorize(ctx, auth.PermLeasesRead); err != nil {
+		return model.Page[model.Lease]{}, err
+	}
+
+	if filter.Status != "" && !isValidLeaseStatus(filter.Status) {
+		return model.Page[model.Lease]{}, fmt.Errorf("%w: status must be 'active', 'ended', or 'upcoming'", ErrInvalidInput)
+	}
+
+	page, err := preparePage(page, "created_at")
+	if err != nil {
+		return model.Page[model.Lease]{}, err
+	}
+
+	leases, err := s.leaseStore.List(ctx, filter, page)
+	return leases, pageError(err, page)
 }
 
 func (s *LeaseService) GetByID(ctx context.Context, id string) (model.Lease, error) {
+	if err := authorize(ctx, auth.PermLeasesRead); err != nil {
+		return model.Lease{}, err
+	}
+
 	lease, err := s.leaseStore.GetByID(ctx, id)
 	if errors.Is(err, store.ErrNotFound) {
 		return model.Lease{}, ErrLeaseNotFound
@@ -43,16 +87,42 @@ func (s *LeaseService) GetByID(ctx context.Context, id string) (model.Lease, err
 }
 
 func (s *LeaseService) GetByPropertyID(ctx context.Context, propertyID string) ([]model.Lease, error) {
+	if err := authorize(ctx, auth.PermLeasesRead); err != nil {
+		return nil, err
+	}
+
 	return s.leaseStore.GetByPropertyID(ctx, propertyID)
 }
 
 func (s *LeaseService) GetByTenantID(ctx context.Context, tenantID string) ([]model.Lease, error) {
+	if err := authorize(ctx, auth.PermLeasesRead); err != nil {
+		return nil, err
+	}
+
 	return s.leaseStore.GetByTenantID(ctx, tenantID)
 }
 
 func (s *LeaseService) Create(ctx context.Context, propertyID, tenantID string, startDate, endDate time.Time, rentAmount, deposit float64) (model.Lease, error) {
+	if err := authorize(ctx, auth.PermLeasesWrite); err != nil {
+		return model.Lease{}, err
+	}
+
+	return s.create(ctx, model.Lease{
+		OrganizationID: organizationID(ctx),
+		PropertyID:     propertyID,
+		TenantID:       tenantID,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		RentAmount:     rentAmount,
+		Deposit:        deposit,
+	})
+}
+
+// create validates and stores a new lease built from the caller's fields.
+// Its ID, status and timestamps are assigned here.
+func (s *LeaseService) create(ctx context.Context, lease model.Lease) (model.Lease, error) {
 	// Validate property exists
-	property, err := s.propertyStore.GetByID(ctx, propertyID)
+	_, err := s.propertyStore.GetByID(ctx, lease.PropertyID)
 	if errors.Is(err, store.ErrNotFound) {
 		return model.Lease{}, fmt.Errorf("%w: property not found", ErrInvalidInput)
 	}
@@ -60,13 +130,8 @@ func (s *LeaseService) Create(ctx context.Context, propertyID, tenantID string,
 		return model.Lease{}, err
 	}
 
-	// Validate property is vacant
-	if property.Status != "vacant" {
-		return model.Lease{}, ErrPropertyNotVacant
-	}
-
 	// Validate tenant exists
-	_, err = s.tenantStore.GetByID(ctx, tenantID)
+	_, err = s.tenantStore.GetByID(ctx, lease.TenantID)
 	if errors.Is(err, store.ErrNotFound) {
 		return model.Lease{}, fmt.Errorf("%w: tenant not found", ErrInvalidInput)
 	}
@@ -75,45 +140,51 @@ func (s *LeaseService) Create(ctx context.Context, propertyID, tenantID string,
 	}
 
 	// Validate dates
-	if endDate.Before(startDate) || endDate.Equal(startDate) {
+	if lease.EndDate.Before(lease.StartDate) || lease.EndDate.Equal(lease.StartDate) {
 		return model.Lease{}, ErrInvalidDateRange
 	}
 
 	// Validate amounts
-	if rentAmount <= 0 {
+	if lease.RentAmount <= 0 {
 		return model.Lease{}, fmt.Errorf("%w: rent amount must be positive", ErrInvalidInput)
 	}
-	if deposit < 0 {
+	if lease.Deposit < 0 {
 		return model.Lease{}, fmt.Errorf("%w: deposit cannot be negative", ErrInvalidInput)
 	}
 
-	lease := model.Lease{
-		ID:         generateID(),
-		PropertyID: propertyID,
-		TenantID:   tenantID,
-		StartDate:  startDate,
-		EndDate:    endDate,
-		RentAmount: rentAmount,
-		Deposit:    deposit,
-		Status:     "active",
-		CreatedAt:  time.Now().UTC(),
-		UpdatedAt:  time.Now().UTC(),
+	// Leases signed ahead of time wait for the lifecycle job to start them
+	status := "active"
+	if dateOnly(lease.StartDate).After(dateOnly(s.clock.Now())) {
+		status = "upcoming"
 	}
 
-	created, err := s.leaseStore.Create(ctx, lease)
+	lease.ID = generateID()
+	lease.Status = status
+	lease.CreatedAt = time.Now().UTC()
+	lease.UpdatedAt = time.Now().UTC()
+
+	var created model.Lease
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Validate no other lease on the property covers these dates
+		if err := s.checkOverlap(ctx, lease.PropertyID, "", lease.StartDate, lease.EndDate); err != nil {
+			return err
+		}
+
+		created, err = s.leaseStore.Create(ctx, lease)
+		return err
+	})
 	if err != nil {
 		return model.Lease{}, err
 	}
 
-	// Update property status to occupied
-	property.Status = "occupied"
-	property.UpdatedAt = time.Now().UTC()
-	s.propertyStore.Update(ctx, property)
-
 	return created, nil
 }
 
 func (s *LeaseService) Update(ctx context.Context, id string, startDate, endDate time.Time, rentAmount, deposit float64, status string) (model.Lease, error) {
+	if err := authorize(ctx, auth.PermLeasesWrite); err != nil {
+		return model.Lease{}, err
+	}
+
 	existing, err := s.leaseStore.GetByID(ctx, id)
 	if errors.Is(err, store.ErrNotFound) {
 		return model.Lease{}, ErrLeaseNotFound
@@ -140,16 +211,7 @@ func (s *LeaseService) Update(ctx context.Context, id string, startDate, endDate
 		return model.Lease{}, fmt.Errorf("%w: deposit cannot be negative", ErrInvalidInput)
 	}
 
-	// If lease is ended, set property back to vacant
-	if status == "ended" && existing.Status != "ended" {
-		property, err := s.propertyStore.GetByID(ctx, existing.PropertyID)
-		if err == nil {
-			property.Status = "vacant"
-			property.UpdatedAt = time.Now().UTC()
-			s.propertyStore.Update(ctx, property)
-		}
-	}
-
+	previousStatus := existing.Status
 	existing.StartDate = startDate
 	existing.EndDate = endDate
 	existing.RentAmount = rentAmount
@@ -157,30 +219,65 @@ func (s *LeaseService) Update(ctx context.Context, id string, startDate, endDate
 	existing.Status = status
 	existing.UpdatedAt = time.Now().UTC()
 
-	return s.leaseStore.Update(ctx, existing)
+	var updated model.Lease
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.checkOverlap(ctx, existing.PropertyID, existing.ID, startDate, endDate); err != nil {
+			return err
+		}
+
+		updated, err = s.leaseStore.Update(ctx, existing)
+		if err != nil {
+			return err
+		}
+
+		// Start the deposit settlement once the lease has ended
+		if status == "ended" && previousStatus != "ended" {
+			return s.deposits.Open(ctx, updated)
+		}
+		return nil
+	})
+	if err != nil {
+		return model.Lease{}, err
+	}
+
+	return updated, nil
 }
 
 func (s *LeaseService) Delete(ctx context.Context, id string) error {
-	// Get lease first to update property status
-	lease, err := s.leaseStore.GetByID(ctx, id)
+	if err := authorize(ctx, auth.PermLeasesDelete); err != nil {
+		return err
+	}
+
+	err := s.leaseStore.Delete(ctx, id)
 	if errors.Is(err, store.ErrNotFound) {
 		return ErrLeaseNotFound
 	}
+	return err
+}
+
+// checkOverlap returns a LeaseConflictError if any lease on the property
+// other than excludeID shares at least one day with the given range. Inside
+// a transaction it locks the property first, so concurrent writers to the
+// same property check and insert one at a time.
+func (s *LeaseService) checkOverlap(ctx context.Context, propertyID, excludeID string, startDate, endDate time.Time) error {
+	if err := s.propertyStore.Lock(ctx, propertyID); err != nil {
+		return err
+	}
+
+	leases, err := s.leaseStore.GetByPropertyID(ctx, propertyID)
 	if err != nil {
 		return err
 	}
 
-	// Set property back to vacant
-	if lease.Status == "active" {
-		property, err := s.propertyStore.GetByID(ctx, lease.PropertyID)
-		if err == nil {
-			property.Status = "vacant"
-			property.UpdatedAt = time.Now().UTC()
-			s.propertyStore.Update(ctx, property)
+	for _, l := range leases {
+		if l.ID == excludeID {
+			continue
+		}
+		if !dateOnly(startDate).After(dateOnly(l.EndDate)) && !dateOnly(l.StartDate).After(dateOnly(endDate)) {
+			return &LeaseConflictError{LeaseID: l.ID}
 		}
 	}
-
-	return s.leaseStore.Delete(ctx, id)
+	return nil
 }
 
 func isValidLeaseStatus(status string) bool {
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+// AdvanceStatuses moves leases through their lifecycle based on today's
+// date: upcoming leases become active on their start date and active ones
+// end the day after their end date, opening their deposit settlement.
+func (s *LeaseService) AdvanceStatuses(ctx context.Context) error {
+	leases, err := s.leaseStore.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	today := dateOnly(s.clock.Now())
+	var ending, starting []model.Lease
+	for _, lease := range leases {
+		switch {
+		case lease.Status != "ended" && dateOnly(lease.EndDate).Before(today):
+			ending = append(ending, lease)
+		case lease.Status == "upcoming" && !dateOnly(lease.StartDate).After(today):
+			starting = append(starting, lease)
+		}
+	}
+
+	for _, lease := range ending {
+		if err := s.endLease(ctx, lease); err != nil {
+			return fmt.Errorf("lease %s: %w", lease.ID, err)
+		}
+	}
+	for _, lease := range starting {
+		if err := s.startLease(ctx, lease); err != nil {
+			return fmt.Errorf("lease %s: %w", lease.ID, err)
+		}
+	}
+
+	if n := len(ending) + len(starting); n > 0 {
+		log.Printf("advanced %d lease statuses (%d started, %d ended)", n, len(starting), len(ending))
+	}
+	return nil
+}
+
+func (s *LeaseService) startLease(ctx context.Context, lease model.Lease) error {
+	lease.Status = "active"
+	lease.UpdatedAt = s.clock.Now()
+
+	_, err := s.leaseStore.Update(ctx, lease)
+	return err
+}
+
+func (s *LeaseService) endLease(ctx context.Context, lease model.Lease) error {
+	lease.Status = "ended"
+	lease.UpdatedAt = s.clock.Now()
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		updated, err := s.leaseStore.Update(ctx, lease)
+		if err != nil {
+			return err
+		}
+		return s.deposits.Open(ctx, updated)
+	})
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+// errRollback undoes everything the test wrote.
+var errRollback = errors.New("rollback")
+
+// TestAdvanceStatuses walks a lease from upcoming to active to ended by
+// moving the clock. It runs against the database in DATABASE_URL, which
+// must have the migrations applied, and leaves it as it found it.
+func TestAdvanceStatuses(t *testing.T) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	db, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	leaseStore := store.NewLeaseStore(db)
+	propertyStore := store.NewPropertyStore(db)
+	tenantStore := store.NewTenantStore(db)
+	depositStore := store.NewDepositStore(db)
+	txManager := store.NewTxManager(db)
+
+	clock := &fixedClock{}
+	payments := NewPaymentService(store.NewPaymentStore(db), store.NewChargeStore(db), leaseStore)
+	deposits := NewDepositService(depositStore, leaseStore, payments, txManager, 30)
+	leases := NewLeaseService(leaseStore, propertyStore, tenantStore, store.NewChargeStore(db), store.NewInvoiceStore(db), deposits, txManager, clock)
+
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	err = txManager.WithinTx(ctx, func(ctx context.Context) error {
+		property, err := propertyStore.Create(ctx, model.Property{
+			ID:             generateID(),
+			OrganizationID: model.DefaultOrganizationID,
+			Address:        model.Address{Street: "1 Test Street", City: "Testville", PostalCode: "00000", Country: "US"},
+			Type:           "apartment",
+			PetPolicy:      "not_allowed",
+			Amenities:      []string{},
+			RentAmount:     1000,
+		})
+		if err != nil {
+			return err
+		}
+		tenant, err := tenantStore.Create(ctx, model.Tenant{
+			ID:             generateID(),
+			OrganizationID: model.DefaultOrganizationID,
+			FirstName:      "Test",
+			LastName:       "Tenant",
+		})
+		if err != nil {
+			return err
+		}
+		lease, err := leaseStore.Create(ctx, model.Lease{
+			ID:             generateID(),
+			OrganizationID: model.DefaultOrganizationID,
+			PropertyID:     property.ID,
+			TenantID:       tenant.ID,
+			StartDate:      start,
+			EndDate:        end,
+			RentAmount:     1000,
+			Deposit:        1000,
+			Status:         "upcoming",
+		})
+		if err != nil {
+			return err
+		}
+
+		steps := []struct {
+			today  time.Time
+			status string
+		}{
+			{start.AddDate(0, 0, -1), "upcoming"},
+			{start, "active"},
+			{end, "active"},
+			{end.AddDate(0, 0, 1), "ended"},
+		}
+		for _, step := range steps {
+			clock.now = step.today.Add(9 * time.Hour)
+			if err := leases.AdvanceStatuses(ctx); err != nil {
+				return err
+			}
+
+			got, err := leaseStore.GetByID(ctx, lease.ID)
+			if err != nil {
+				return err
+			}
+			if got.Status != step.status {
+				t.Errorf("on %s: status = %q, want %q", step.today.Format("2006-01-02"), got.Status, step.status)
+			}
+			if got.Status != lease.Status && !got.UpdatedAt.Equal(clock.now) {
+				t.Errorf("on %s: updated_at = %s, want the clock's %s", step.today.Format("2006-01-02"), got.UpdatedAt, clock.now)
+			}
+			lease = got
+		}
+
+		if _, err := depositStore.GetByLeaseID(ctx, lease.ID); err != nil {
+			t.Errorf("deposit settlement not opened when the lease ended: %v", err)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrLeasePartyNotFound = errors.New("lease party not found")
+	ErrLeasePartyExists   = errors.New("tenant is already a party to this lease")
+)
+
+type LeasePartyService struct {
+	partyStore     *store.LeasePartyStore
+	leaseStore     *store.LeaseStore
+	tenantStore    *store.TenantStore
+	guarantorStore *store.GuarantorStore
+	tx             *store.TxManager
+}
+
+func NewLeasePartyService(lps *store.LeasePartyStore, ls *store.LeaseStore, ts *store.TenantStore, gs *store.GuarantorStore, tx *store.TxManager) *LeasePartyService {
+	return &LeasePartyService{
+		partyStore:     lps,
+		leaseStore:     ls,
+		tenantStore:    ts,
+		guarantorStore: gs,
+		tx:             tx,
+	}
+}
+
+func (s *LeasePartyService) GetByLeaseID(ctx context.Context, leaseID string) ([]model.LeaseParty, error) {
+	if _, err := s.getLease(ctx, leaseID); err != nil {
+		return nil, err
+	}
+	return s.partyStore.GetByLeaseID(ctx, leaseID)
+}
+
+// Add makes a tenant a party to a lease. The primary party is set when the
+// lease is created and can only be changed with UpdateRole. A guarantor
+// party is also linked to the lease as a guarantor.
+func (s *LeasePartyService) Add(ctx context.Context, leaseID, tenantID, role string) (model.LeaseParty, error) {
+	if _, err := s.getLease(ctx, leaseID); err != nil {
+		return model.LeaseParty{}, err
+	}
+
+	tenant, err := s.tenantStore.GetByID(ctx, tenantID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.LeaseParty{}, fmt.Errorf("%w: tenant not found", ErrInvalidInput)
+	}
+	if err != nil {
+		return model.LeaseParty{}, err
+	}
+
+	if !isValidPartyRole(role) || role == "primary" {
+		return model.LeaseParty{}, fmt.Errorf("%w: role must be 'co_tenant', 'guarantor' or 'occupant'", ErrInvalidInput)
+	}
+
+	party := model.LeaseParty{
+		LeaseID:   leaseID,
+		TenantID:  tenantID,
+		Role:      role,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.partyStore.Create(ctx, party); err != nil {
+			return err
+		}
+		if role == "guarantor" {
+			return s.linkGuarantor(ctx, leaseID, tenant)
+		}
+		return nil
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		return model.LeaseParty{}, ErrLeasePartyExists
+	}
+	if err != nil {
+		return model.LeaseParty{}, err
+	}
+
+	return s.partyStore.GetByID(ctx, leaseID, tenantID)
+}
+
+// UpdateRole changes a party's role. Promoting a party to primary demotes
+// the previous primary to co-tenant and makes the party the lease's tenant.
+// The party's guarantor link follows the guarantor role.
+func (s *LeasePartyService) UpdateRole(ctx context.Context, leaseID, tenantID, role string) (model.LeaseParty, error) {
+	lease, err := s.getLease(ctx, leaseID)
+	if err != nil {
+		return model.LeaseParty{}, err
+	}
+
+	party, err := s.partyStore.GetByID(ctx, leaseID, tenantID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.LeaseParty{}, ErrLeasePartyNotFound
+	}
+	if err != nil {
+		return model.LeaseParty{}, err
+	}
+
+	if !isValidPartyRole(role) {
+		return model.LeaseParty{}, fmt.Errorf("%w: role must be 'primary', 'co_tenant', 'guarantor' or 'occupant'", ErrInvalidInput)
+	}
+	if party.Role == "primary" && role != "primary" {
+		return model.LeaseParty{}, fmt.Errorf("%w: promote another party to primary instead", ErrInvalidInput)
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if role == "primary" && party.Role != "primary" {
+			if err := s.partyStore.UpdateRole(ctx, leaseID, lease.TenantID, "co_tenant"); err != nil {
+				return err
+			}
+			lease.TenantID = tenantID
+			lease.UpdatedAt = time.Now().UTC()
+			if _, err := s.leaseStore.Update(ctx, lease); err != nil {
+				return err
+			}
+		}
+		if err := s.partyStore.UpdateRole(ctx, leaseID, tenantID, role); err != nil {
+			return err
+		}
+
+		switch {
+		case role == "guarantor" && party.Role != "guarantor":
+			tenant, err := s.tenantStore.GetByID(ctx, tenantID)
+			if err != nil {
+				return err
+			}
+			return s.linkGuarantor(ctx, leaseID, tenant)
+		case role != "guarantor" && party.Role == "guarantor":
+			return s.unlinkGuarantor(ctx, leaseID, tenantID)
+		}
+		return nil
+	})
+	if err != nil {
+		return model.LeaseParty{}, err
+	}
+
+	return s.partyStore.GetByID(ctx, leaseID, tenantID)
+}
+
+func (s *LeasePartyService) Remove(ctx context.Context, leaseID, tenantID string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if _, err := s.getLease(ctx, leaseID); err != nil {
+		return err
+	}
+
+	party, err := s.partyStore.GetByID(ctx, leaseID, tenantID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrLeasePartyNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if party.Role == "primary" {
+		return fmt.Errorf("%w: the primary party cannot be removed", ErrInvalidInput)
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.partyStore.Delete(ctx, leaseID, tenantID); err != nil {
+			return err
+		}
+		if party.Role == "guarantor" {
+			return s.unlinkGuarantor(ctx, leaseID, tenantID)
+		}
+		return nil
+	})
+}
+
+// linkGuarantor links the tenant's guarantor record to the lease, creating
+// the record from the tenant's details the first time they guarantee one.
+func (s *LeasePartyService) linkGuarantor(ctx context.Context, leaseID string, tenant model.Tenant) error {
+	id := partyGuarantorID(tenant.ID)
+
+	_, err := s.guarantorStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		_, err = s.guarantorStore.Create(ctx, model.Guarantor{
+			ID:             id,
+			OrganizationID: tenant.OrganizationID,
+			FirstName:      tenant.FirstName,
+			LastName:       tenant.LastName,
+			Email:          tenant.Email,
+			Phone:          tenant.Phone,
+			Address:        formatAddress(tenant.Address),
+			CreatedAt:      time.Now().UTC(),
+			UpdatedAt:      time.Now().UTC(),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.guarantorStore.Link(ctx, leaseID, id)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil
+	}
+	return err
+}
+
+func (s *LeasePartyService) unlinkGuarantor(ctx context.Context, leaseID, tenantID string) error {
+	err := s.guarantorStore.Unlink(ctx, leaseID, partyGuarantorID(tenantID))
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// partyGuarantorID is the id of the guarantor record that stands for a
+// tenant who is a guarantor party.
+func partyGuarantorID(tenantID string) string {
+	return "guarantor-" + tenantID
+}
+
+func (s *LeasePartyService) getLease(ctx context.Context, leaseID string) (model.Lease, error) {
+	lease, err := s.leaseStore.GetByID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Lease{}, ErrLeaseNotFound
+	}
+	return lease, err
+}
+
+func isValidPartyRole(role string) bool {
+	switch role {
+	case "primary", "co_tenant", "guarantor", "occupant":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrLeaseAlreadyRenewed = errors.New("lease has already been renewed")
+)
+
+// Escalation describes how rent changes on renewal. Type is "none",
+// "fixed" (Value is added to the rent), "percentage" (rent grows by Value
+// percent) or "index" (rent is scaled by CurrentIndex / BaseIndex, as with
+// a CPI adjustment).
+type Escalation struct {
+	Type         string
+	Value        float64
+	BaseIndex    float64
+	CurrentIndex float64
+}
+
+// Renew creates the successor of a lease. It starts the day after the
+// current lease ends and, unless endDate is given, runs for the same term.
+// The deposit is carried over instead of being collected again.
+func (s *LeaseService) Renew(ctx context.Context, id string, endDate *time.Time, escalation Escalation) (model.Lease, error) {
+	if err := authorize(ctx, auth.PermLeasesWrite); err != nil {
+		return model.Lease{}, err
+	}
+
+	current, err := s.leaseStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Lease{}, ErrLeaseNotFound
+	}
+	if err != nil {
+		return model.Lease{}, err
+	}
+	if current.Termination != nil {
+		return model.Lease{}, ErrLeaseTerminated
+	}
+
+	_, err = s.leaseStore.GetByPreviousLeaseID(ctx, id)
+	if err == nil {
+		return model.Lease{}, ErrLeaseAlreadyRenewed
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return model.Lease{}, err
+	}
+
+	rentAmount, err := escalate(current.RentAmount, escalation)
+	if err != nil {
+		return model.Lease{}, err
+	}
+
+	startDate := dateOnly(current.EndDate).AddDate(0, 0, 1)
+	if endDate == nil {
+		next := nextTermEnd(current, startDate)
+		endDate = &next
+	}
+
+	return s.create(ctx, model.Lease{
+		OrganizationID:  current.OrganizationID,
+		PropertyID:      current.PropertyID,
+		TenantID:        current.TenantID,
+		StartDate:       startDate,
+		EndDate:         *endDate,
+		RentAmount:      rentAmount,
+		Deposit:         current.Deposit,
+		PreviousLeaseID: &current.ID,
+	})
+}
+
+func escalate(rent float64, e Escalation) (float64, error) {
+	switch e.Type {
+	case "", "none":
+		return rent, nil
+	case "fixed":
+		rent += e.Value
+	case "percentage":
+		if e.Value <= -100 {
+			return 0, fmt.Errorf("%w: percentage must be greater than -100", ErrInvalidInput)
+		}
+		rent *= 1 + e.Value/100
+	case "index":
+		if e.BaseIndex <= 0 || e.CurrentIndex <= 0 {
+			return 0, fmt.Errorf("%w: base_index and current_index must be positive", ErrInvalidInput)
+		}
+		rent *= e.CurrentIndex / e.BaseIndex
+	default:
+		return 0, fmt.Errorf("%w: escalation type must be 'none', 'fixed', 'percentage' or 'index'", ErrInvalidInput)
+	}
+	return roundCents(rent), nil
+}
+
+// nextTermEnd gives a renewal starting on start the same term as lease.
+// Terms made of whole months (the usual case) are repeated month for month
+// so leap years do not shift the end date; anything else keeps its length
+// in days.
+func nextTermEnd(lease model.Lease, start time.Time) time.Time {
+	leaseStart := dateOnly(lease.StartDate)
+	afterEnd := dateOnly(lease.EndDate).AddDate(0, 0, 1)
+
+	months := (afterEnd.Year()-leaseStart.Year())*12 + int(afterEnd.Month()-leaseStart.Month())
+	if months > 0 && addMonths(leaseStart, months).Equal(afterEnd) {
+		return addMonths(start, months).AddDate(0, 0, -1)
+	}
+	return start.AddDate(0, 0, daysBetween(leaseStart, afterEnd)-1)
+}
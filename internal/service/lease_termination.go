@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrLeaseTerminated = errors.New("lease has already been terminated")
+	ErrLeaseHasRenewal = errors.New("lease has been renewed; terminate the renewal instead")
+)
+
+// Terminate records a tenant's notice or an early termination. The lease end
+// date is moved up to the move-out date, which the lifecycle job then acts
+// on, while the contractual end date is kept for reporting. A termination
+// fee is billed to the lease ledger, and invoices already raised are cut
+// back to the move-out date. A lease that has been renewed cannot be
+// terminated, as its renewal would stay in place.
+func (s *LeaseService) Terminate(ctx context.Context, id string, noticeDate, moveOutDate time.Time, reason string, fee float64) (model.Lease, error) {
+	if err := authorize(ctx, auth.PermLeasesWrite); err != nil {
+		return model.Lease{}, err
+	}
+
+	lease, err := s.leaseStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Lease{}, ErrLeaseNotFound
+	}
+	if err != nil {
+		return model.Lease{}, err
+	}
+
+	if lease.Termination != nil {
+		return model.Lease{}, ErrLeaseTerminated
+	}
+	if lease.Status == "ended" {
+		return model.Lease{}, fmt.Errorf("%w: lease has already ended", ErrInvalidInput)
+	}
+
+	_, err = s.leaseStore.GetByPreviousLeaseID(ctx, id)
+	if err == nil {
+		return model.Lease{}, ErrLeaseHasRenewal
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return model.Lease{}, err
+	}
+
+	noticeDate, moveOutDate = dateOnly(noticeDate), dateOnly(moveOutDate)
+	if moveOutDate.Before(dateOnly(lease.StartDate)) || moveOutDate.After(dateOnly(lease.EndDate)) {
+		return model.Lease{}, fmt.Errorf("%w: move-out date must fall within the lease", ErrInvalidInput)
+	}
+	if noticeDate.After(moveOutDate) {
+		return model.Lease{}, fmt.Errorf("%w: notice date cannot be after the move-out date", ErrInvalidInput)
+	}
+	if fee < 0 {
+		return model.Lease{}, fmt.Errorf("%w: fee cannot be negative", ErrInvalidInput)
+	}
+
+	lease.Termination = &model.LeaseTermination{
+		NoticeDate:      noticeDate,
+		MoveOutDate:     moveOutDate,
+		Reason:          reason,
+		Fee:             roundCents(fee),
+		OriginalEndDate: dateOnly(lease.EndDate),
+	}
+	lease.EndDate = moveOutDate
+	lease.UpdatedAt = time.Now().UTC()
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		lease, err = s.leaseStore.Update(ctx, lease)
+		if err != nil {
+			return err
+		}
+
+		// Bill only up to the move-out date: drop invoices for later periods
+		// and prorate the one the move-out falls in
+		if err := s.invoiceStore.DeleteAfter(ctx, lease.ID, moveOutDate); err != nil {
+			return err
+		}
+		if periods := rentPeriods(lease, moveOutDate); len(periods) > 0 {
+			last := periods[len(periods)-1]
+			if err := s.invoiceStore.Reprice(ctx, lease.ID, last.Start, last.End, last.Amount); err != nil {
+				return err
+			}
+		}
+
+		if lease.Termination.Fee > 0 {
+			_, err := s.chargeStore.Create(ctx, model.LeaseCharge{
+				ID:          generateID(),
+				LeaseID:     lease.ID,
+				Type:        "early_termination",
+				Description: "Early termination fee",
+				Amount:      lease.Termination.Fee,
+				ChargeDate:  noticeDate,
+				CreatedAt:   time.Now().UTC(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// A move-out in the past takes effect straight away
+		if moveOutDate.Before(dateOnly(s.clock.Now())) {
+			if err := s.endLease(ctx, lease); err != nil {
+				return err
+			}
+			lease.Status = "ended"
+		}
+		return nil
+	})
+	if err != nil {
+		return model.Lease{}, err
+	}
+
+	return lease, nil
+}
@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrMaintenanceRequestNotFound = errors.New("maintenance request not found")
+	ErrMaintenanceTransition      = errors.New("maintenance request cannot move to that status")
+	ErrMaintenanceRequestClosed   = errors.New("maintenance request is closed")
+)
+
+// maintenanceTransitions lists the statuses each status may move to. A
+// request is reassigned by assigning it again; resolved requests can be
+// reopened and closed ones are final.
+var maintenanceTransitions = map[string][]string{
+	"open":        {"assigned", "in_progress", "closed"},
+	"assigned":    {"assigned", "in_progress", "closed"},
+	"in_progress": {"resolved", "closed"},
+	"resolved":    {"open", "assigned", "closed"},
+}
+
+// MaintenanceDetails are the fields staff fill in on a request. Priority
+// defaults to normal and Category to general.
+type MaintenanceDetails struct {
+	TenantID    *string
+	Title       string
+	Description string
+	Priority    string
+	Category    string
+}
+
+type MaintenanceService struct {
+	store         *store.MaintenanceStore
+	propertyStore *store.PropertyStore
+	tenantStore   *store.TenantStore
+	vendorStore   *store.VendorStore
+	expenseStore  *store.ExpenseStore
+}
+
+func NewMaintenanceService(s *store.MaintenanceStore, ps *store.PropertyStore, ts *store.TenantStore, vs *store.VendorStore, es *store.ExpenseStore) *MaintenanceService {
+	return &MaintenanceService{
+		store:         s,
+		propertyStore: ps,
+		tenantStore:   ts,
+		vendorStore:   vs,
+		expenseStore:  es,
+	}
+}
+
+func (s *MaintenanceService) List(ctx context.Context, filter model.MaintenanceFilter) ([]model.MaintenanceRequest, error) {
+	if filter.Status != "" && !isValidMaintenanceStatus(filter.Status) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidInput, filter.Status)
+	}
+	if filter.Priority != "" && !isValidMaintenancePriority(filter.Priority) {
+		return nil, fmt.Errorf("%w: unknown priority %q", ErrInvalidInput, filter.Priority)
+	}
+	if filter.Category != "" && !isValidMaintenanceCategory(filter.Category) {
+		return nil, fmt.Errorf("%w: unknown category %q", ErrInvalidInput, filter.Category)
+	}
+	return s.store.GetAll(ctx, filter)
+}
+
+// GetByPropertyID returns the requests for a property matching the filter.
+func (s *MaintenanceService) GetByPropertyID(ctx context.Context, propertyID string, filter model.MaintenanceFilter) ([]model.MaintenanceRequest, error) {
+	if _, err := s.property(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	filter.PropertyID = propertyID
+	return s.List(ctx, filter)
+}
+
+// GetByTenantID returns the requests the tenant reported.
+func (s *MaintenanceService) GetByTenantID(ctx context.Context, tenantID string) ([]model.MaintenanceRequest, error) {
+	return s.store.GetByTenantID(ctx, tenantID)
+}
+
+func (s *MaintenanceService) GetByID(ctx context.Context, id string) (model.MaintenanceRequest, error) {
+	request, err := s.store.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.MaintenanceRequest{}, ErrMaintenanceRequestNotFound
+	}
+	return request, err
+}
+
+// Create opens a request for a property on behalf of staff, optionally
+// naming the tenant who reported it.
+func (s *MaintenanceService) Create(ctx context.Context, propertyID string, details MaintenanceDetails) (model.MaintenanceRequest, error) {
+	property, err := s.property(ctx, propertyID)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+
+	details, err = s.prepare(ctx, details)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+
+	request := model.MaintenanceRequest{
+		ID:             generateID(),
+		OrganizationID: property.OrganizationID,
+		PropertyID:     property.ID,
+		Status:         "open",
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+	applyMaintenanceDetails(&request, details)
+
+	return s.store.Create(ctx, request)
+}
+
+// Report opens a request from a tenant about the property they lease.
+func (s *MaintenanceService) Report(ctx context.Context, lease model.Lease, tenantID, title, description string) (model.MaintenanceRequest, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return model.MaintenanceRequest{}, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+
+	request := model.MaintenanceRequest{
+		ID:             generateID(),
+		OrganizationID: lease.OrganizationID,
+		PropertyID:     lease.PropertyID,
+		LeaseID:        &lease.ID,
+		TenantID:       &tenantID,
+		Title:          title,
+		Description:    strings.TrimSpace(description),
+		Priority:       "normal",
+		Category:       "general",
+		Status:         "open",
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+
+	return s.store.Create(ctx, request)
+}
+
+// Update changes the details of a request that is not yet closed. The
+// tenant who reported it cannot be changed.
+func (s *MaintenanceService) Update(ctx context.Context, id string, details MaintenanceDetails) (model.MaintenanceRequest, error) {
+	request, err := s.open(ctx, id)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+	details.TenantID = request.TenantID
+
+	details, err = s.prepare(ctx, details)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+
+	applyMaintenanceDetails(&request, details)
+	request.UpdatedAt = time.Now().UTC()
+
+	return s.store.Update(ctx, request)
+}
+
+func (s *MaintenanceService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	err := s.store.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrMaintenanceRequestNotFound
+	}
+	return err
+}
+
+// Assign hands the request to a vendor, replacing any earlier assignment.
+// Vendors whose insurance has lapsed cannot be assigned.
+func (s *MaintenanceService) Assign(ctx context.Context, id, vendorID string) (model.MaintenanceRequest, error) {
+	vendor, err := s.vendorStore.GetByID(ctx, vendorID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.MaintenanceRequest{}, fmt.Errorf("%w: vendor not found", ErrInvalidInput)
+	}
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+	if insuranceLapsed(vendor, dateOnly(time.Now().UTC())) {
+		return model.MaintenanceRequest{}, ErrVendorInsuranceLapsed
+	}
+
+	return s.move(ctx, id, "assigned", func(m *model.MaintenanceRequest) {
+		m.VendorID = &vendorID
+	})
+}
+
+// Start marks work on the request as under way.
+func (s *MaintenanceService) Start(ctx context.Context, id string) (model.MaintenanceRequest, error) {
+	return s.move(ctx, id, "in_progress", nil)
+}
+
+func (s *MaintenanceService) Resolve(ctx context.Context, id string) (model.MaintenanceRequest, error) {
+	return s.move(ctx, id, "resolved", func(m *model.MaintenanceRequest) {
+		now := time.Now().UTC()
+		m.ResolvedAt = &now
+	})
+}
+
+func (s *MaintenanceService) Close(ctx context.Context, id string) (model.MaintenanceRequest, error) {
+	return s.move(ctx, id, "closed", func(m *model.MaintenanceRequest) {
+		now := time.Now().UTC()
+		m.ClosedAt = &now
+	})
+}
+
+// Reopen sends a resolved request back to its vendor, or back to open if
+// it has none.
+func (s *MaintenanceService) Reopen(ctx context.Context, id string) (model.MaintenanceRequest, error) {
+	request, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+	if request.Status != "resolved" {
+		return model.MaintenanceRequest{}, ErrMaintenanceTransition
+	}
+
+	status := "open"
+	if request.VendorID != nil {
+		status = "assigned"
+	}
+	return s.move(ctx, id, status, func(m *model.MaintenanceRequest) {
+		m.ResolvedAt = nil
+	})
+}
+
+func (s *MaintenanceService) Comments(ctx context.Context, id string) ([]model.MaintenanceComment, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.store.GetComments(ctx, id)
+}
+
+// AddComment records a note on the request from the signed-in user.
+func (s *MaintenanceService) AddComment(ctx context.Context, id, body string) (model.MaintenanceComment, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return model.MaintenanceComment{}, err
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return model.MaintenanceComment{}, fmt.Errorf("%w: body is required", ErrInvalidInput)
+	}
+
+	comment := model.MaintenanceComment{
+		ID:        generateID(),
+		RequestID: id,
+		Body:      body,
+		CreatedAt: time.Now().UTC(),
+	}
+	if user, ok := auth.UserFrom(ctx); ok {
+		comment.AuthorID = &user.ID
+		comment.AuthorName = user.Name
+	}
+
+	return s.store.CreateComment(ctx, comment)
+}
+
+func (s *MaintenanceService) Costs(ctx context.Context, id string) ([]model.Expense, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.expenseStore.GetByMaintenanceRequestID(ctx, id)
+}
+
+// AddCost records money spent on the request as a repairs expense of its
+// property, so it is deducted in the owner's statement.
+func (s *MaintenanceService) AddCost(ctx context.Context, id string, incurredOn time.Time, description string, amount float64) (model.Expense, error) {
+	request, err := s.open(ctx, id)
+	if err != nil {
+		return model.Expense{}, err
+	}
+
+	if amount <= 0 {
+		return model.Expense{}, fmt.Errorf("%w: amount must be positive", ErrInvalidInput)
+	}
+
+	description = strings.TrimSpace(description)
+	if description == "" {
+		description = request.Title
+	}
+
+	expense := model.Expense{
+		ID:                   generateID(),
+		PropertyID:           request.PropertyID,
+		MaintenanceRequestID: &request.ID,
+		IncurredOn:           dateOnly(incurredOn),
+		Category:             "repairs",
+		Description:          description,
+		Amount:               roundCents(amount),
+		CreatedAt:            time.Now().UTC(),
+	}
+
+	return s.expenseStore.Create(ctx, expense)
+}
+
+func (s *MaintenanceService) DeleteCost(ctx context.Context, id, costID string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if _, err := s.open(ctx, id); err != nil {
+		return err
+	}
+
+	err := s.expenseStore.DeleteMaintenanceCost(ctx, id, costID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrExpenseNotFound
+	}
+	return err
+}
+
+// move changes the request's status if the workflow allows it, applying
+// update to the request first.
+func (s *MaintenanceService) move(ctx context.Context, id, status string, update func(*model.MaintenanceRequest)) (model.MaintenanceRequest, error) {
+	request, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+	if !canTransition(maintenanceTransitions, request.Status, status) {
+		return model.MaintenanceRequest{}, ErrMaintenanceTransition
+	}
+
+	if update != nil {
+		update(&request)
+	}
+	request.Status = status
+	request.UpdatedAt = time.Now().UTC()
+
+	return s.store.Update(ctx, request)
+}
+
+// open returns the request, or ErrMaintenanceRequestClosed if it can no
+// longer be changed.
+func (s *MaintenanceService) open(ctx context.Context, id string) (model.MaintenanceRequest, error) {
+	request, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+	if request.Status == "closed" {
+		return model.MaintenanceRequest{}, ErrMaintenanceRequestClosed
+	}
+	return request, nil
+}
+
+func (s *MaintenanceService) property(ctx context.Context, propertyID string) (model.Property, error) {
+	property, err := s.propertyStore.GetByID(ctx, propertyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Property{}, ErrPropertyNotFound
+	}
+	return property, err
+}
+
+// prepare fills in defaults and validates the details.
+func (s *MaintenanceService) prepare(ctx context.Context, details MaintenanceDetails) (MaintenanceDetails, error) {
+	details.Title = strings.TrimSpace(details.Title)
+	details.Description = strings.TrimSpace(details.Description)
+	if details.Priority == "" {
+		details.Priority = "normal"
+	}
+	if details.Category == "" {
+		details.Category = "general"
+	}
+
+	if details.Title == "" {
+		return MaintenanceDetails{}, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+	if !isValidMaintenancePriority(details.Priority) {
+		return MaintenanceDetails{}, fmt.Errorf("%w: priority must be 'low', 'normal', 'high' or 'urgent'", ErrInvalidInput)
+	}
+	if !isValidMaintenanceCategory(details.Category) {
+		return MaintenanceDetails{}, fmt.Errorf("%w: category must be 'plumbing', 'electrical', 'hvac', 'appliance', 'structural', 'pest_control' or 'general'", ErrInvalidInput)
+	}
+
+	if details.TenantID != nil {
+		_, err := s.tenantStore.GetByID(ctx, *details.TenantID)
+		if errors.Is(err, store.ErrNotFound) {
+			return MaintenanceDetails{}, fmt.Errorf("%w: tenant not found", ErrInvalidInput)
+		}
+		if err != nil {
+			return MaintenanceDetails{}, err
+		}
+	}
+	return details, nil
+}
+
+func applyMaintenanceDetails(m *model.MaintenanceRequest, details MaintenanceDetails) {
+	m.TenantID = details.TenantID
+	m.Title = details.Title
+	m.Description = details.Description
+	m.Priority = details.Priority
+	m.Category = details.Category
+}
+
+func isValidMaintenanceStatus(status string) bool {
+	switch status {
+	case "open", "assigned", "in_progress", "resolved", "closed":
+		return true
+	}
+	return false
+}
+
+func isValidMaintenancePriority(priority string) bool {
+	switch priority {
+	case "low", "normal", "high", "urgent":
+		return true
+	}
+	return false
+}
+
+func isValidMaintenanceCategory(category string) bool {
+	switch category {
+	case "plumbing", "electrical", "hvac", "appliance", "structural", "pest_control", "general":
+		return true
+	}
+	return false
+}
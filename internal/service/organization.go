@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+type OrganizationService struct {
+	store     *store.OrganizationStore
+	users     *UserService
+	txManager *store.TxManager
+}
+
+func NewOrganizationService(s *store.OrganizationStore, users *UserService, tx *store.TxManager) *OrganizationService {
+	return &OrganizationService{
+		store:     s,
+		users:     users,
+		txManager: tx,
+	}
+}
+
+// Current returns the organization of the signed-in user.
+func (s *OrganizationService) Current(ctx context.Context) (model.Organization, error) {
+	id := organizationID(ctx)
+	if id == "" {
+		return model.Organization{}, ErrForbidden
+	}
+
+	organization, err := s.store.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Organization{}, ErrOrganizationNotFound
+	}
+	return organization, err
+}
+
+func (s *OrganizationService) List(ctx context.Context) ([]model.Organization, error) {
+	if err := authorizeDeployment(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetAll(ctx)
+}
+
+// Create provisions an organization for a new landlord together with its
+// first staff account, who can then sign in and add everyone else.
+func (s *OrganizationService) Create(ctx context.Context, name, adminEmail, adminName, adminPassword string) (model.Organization, error) {
+	if err := authorizeDeployment(ctx); err != nil {
+		return model.Organization{}, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.Organization{}, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	organization := model.Organization{
+		ID:        generateID(),
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if organization, err = s.store.Create(ctx, organization); err != nil {
+			return err
+		}
+		_, err = s.users.createAdmin(ctx, organization.ID, adminEmail, adminName, adminPassword)
+		return err
+	})
+	if err != nil {
+		return model.Organization{}, err
+	}
+	return organization, nil
+}
+
+// authorizeDeployment allows only staff of the default organization, who
+// run the deployment as a whole.
+func authorizeDeployment(ctx context.Context) error {
+	if err := authorize(ctx, auth.PermOrganizationsManage); err != nil {
+		return err
+	}
+	if organizationID(ctx) != model.DefaultOrganizationID {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrOwnerNotFound      = errors.New("owner not found")
+	ErrOwnerHasProperties = errors.New("owner still has properties")
+	ErrOwnerUserTaken     = errors.New("this user is already linked to another owner")
+)
+
+// OwnerDetails are the editable fields of an owner. UserID, if set, must
+// name a user with the owner role.
+type OwnerDetails struct {
+	UserID               *string
+	Name                 string
+	Email                string
+	Phone                string
+	Address              model.Address
+	ManagementFeePercent float64
+}
+
+type OwnerService struct {
+	ownerStore    *store.OwnerStore
+	propertyStore *store.PropertyStore
+	userStore     *store.UserStore
+	tx            *store.TxManager
+}
+
+func NewOwnerService(os *store.OwnerStore, ps *store.PropertyStore, us *store.UserStore, tx *store.TxManager) *OwnerService {
+	return &OwnerService{
+		ownerStore:    os,
+		propertyStore: ps,
+		userStore:     us,
+		tx:            tx,
+	}
+}
+
+// List returns the owners the signed-in user may see; for an owner that is
+// just their own record.
+func (s *OwnerService) List(ctx context.Context) ([]model.Owner, error) {
+	if err := authorize(ctx, auth.PermOwnersRead); err != nil {
+		return nil, err
+	}
+
+	return s.ownerStore.GetAll(ctx)
+}
+
+func (s *OwnerService) GetByID(ctx context.Context, id string) (model.Owner, error) {
+	if err := authorize(ctx, auth.PermOwnersRead); err != nil {
+		return model.Owner{}, err
+	}
+
+	owner, err := s.ownerStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Owner{}, ErrOwnerNotFound
+	}
+	return owner, err
+}
+
+// Properties returns the properties managed for the owner with their
+// occupancy as of the given date.
+func (s *OwnerService) Properties(ctx context.Context, id string, asOf time.Time) ([]model.Property, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.propertyStore.GetByOwnerID(ctx, id, asOf)
+}
+
+func (s *OwnerService) Create(ctx context.Context, details OwnerDetails) (model.Owner, error) {
+	if err := authorize(ctx, auth.PermOwnersWrite); err != nil {
+		return model.Owner{}, err
+	}
+
+	details, err := s.prepare(ctx, details)
+	if err != nil {
+		return model.Owner{}, err
+	}
+
+	owner := model.Owner{
+		ID:             generateID(),
+		OrganizationID: organizationID(ctx),
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+	applyOwnerDetails(&owner, details)
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		owner, err = s.ownerStore.Create(ctx, owner)
+		if err != nil {
+			return err
+		}
+		return s.ownerStore.SetFeeRate(ctx, owner.ID, dateOnly(owner.CreatedAt), owner.ManagementFeePercent)
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		return model.Owner{}, ErrOwnerUserTaken
+	}
+	if err != nil {
+		return model.Owner{}, err
+	}
+	return owner, nil
+}
+
+func (s *OwnerService) Update(ctx context.Context, id string, details OwnerDetails) (model.Owner, error) {
+	if err := authorize(ctx, auth.PermOwnersWrite); err != nil {
+		return model.Owner{}, err
+	}
+
+	existing, err := s.ownerStore.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Owner{}, ErrOwnerNotFound
+	}
+	if err != nil {
+		return model.Owner{}, err
+	}
+
+	details, err = s.prepare(ctx, details)
+	if err != nil {
+		return model.Owner{}, err
+	}
+
+	previousFee := existing.ManagementFeePercent
+	applyOwnerDetails(&existing, details)
+	existing.UpdatedAt = time.Now().UTC()
+
+	// A fee change applies from today on; earlier months keep the rate they
+	// were charged at.
+	var owner model.Owner
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		owner, err = s.ownerStore.Update(ctx, existing)
+		if err != nil {
+			return err
+		}
+		if owner.ManagementFeePercent == previousFee {
+			return nil
+		}
+		return s.ownerStore.SetFeeRate(ctx, owner.ID, dateOnly(owner.UpdatedAt), owner.ManagementFeePercent)
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		return model.Owner{}, ErrOwnerUserTaken
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Owner{}, ErrOwnerNotFound
+	}
+	if err != nil {
+		return model.Owner{}, err
+	}
+	return owner, nil
+}
+
+// Delete removes an owner. Their properties must be reassigned or deleted
+// first.
+func (s *OwnerService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermOwnersDelete); err != nil {
+		return err
+	}
+
+	properties, err := s.propertyStore.GetByOwnerID(ctx, id, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if len(properties) > 0 {
+		return ErrOwnerHasProperties
+	}
+
+	err = s.ownerStore.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrOwnerNotFound
+	}
+	return err
+}
+
+// Statement rolls up the owner's properties for the calendar month
+// containing month: rent collected that month, the management fee on it,
+// the expenses incurred and what is left for the owner. The fee is charged
+// at the rate in effect on the last day of the month, so later fee changes
+// leave past statements as they were.
+func (s *OwnerService) Statement(ctx context.Context, id string, month time.Time) (model.OwnerStatement, error) {
+	owner, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.OwnerStatement{}, err
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := addMonths(start, 1).AddDate(0, 0, -1)
+
+	feePercent, err := s.ownerStore.FeeRateOn(ctx, id, end)
+	if errors.Is(err, store.ErrNotFound) {
+		feePercent, err = owner.ManagementFeePercent, nil
+	}
+	if err != nil {
+		return model.OwnerStatement{}, err
+	}
+
+	lines, err := s.ownerStore.StatementLines(ctx, id, start, end)
+	if err != nil {
+		return model.OwnerStatement{}, err
+	}
+
+	statement := model.OwnerStatement{
+		Owner:                owner,
+		Month:                start.Format("2006-01"),
+		PeriodStart:          start,
+		PeriodEnd:            end,
+		ManagementFeePercent: feePercent,
+		Properties:           lines,
+	}
+	for i := range statement.Properties {
+		line := &statement.Properties[i]
+		line.RentCollected = roundCents(line.RentCollected)
+		line.Expenses = roundCents(line.Expenses)
+		line.ManagementFee = roundCents(line.RentCollected * feePercent / 100)
+		line.Net = roundCents(line.RentCollected - line.ManagementFee - line.Expenses)
+
+		statement.Totals.RentCollected = roundCents(statement.Totals.RentCollected + line.RentCollected)
+		statement.Totals.ManagementFee = roundCents(statement.Totals.ManagementFee + line.ManagementFee)
+		statement.Totals.Expenses = roundCents(statement.Totals.Expenses + line.Expenses)
+		statement.Totals.Net = roundCents(statement.Totals.Net + line.Net)
+	}
+
+	return statement, nil
+}
+
+// prepare normalizes the details and validates the result.
+func (s *OwnerService) prepare(ctx context.Context, details OwnerDetails) (OwnerDetails, error) {
+	details.Name = strings.TrimSpace(details.Name)
+	details.Email = strings.TrimSpace(details.Email)
+	details.Address = normalizeAddress(details.Address)
+
+	if details.Name == "" {
+		return OwnerDetails{}, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if details.ManagementFeePercent < 0 || details.ManagementFeePercent > 100 {
+		return OwnerDetails{}, fmt.Errorf("%w: management fee percent must be between 0 and 100", ErrInvalidInput)
+	}
+	if !details.Address.IsZero() {
+		if err := validateAddress(details.Address); err != nil {
+			return OwnerDetails{}, err
+		}
+	}
+
+	if details.UserID != nil {
+		user, err := s.userStore.GetByID(ctx, *details.UserID)
+		if errors.Is(err, store.ErrNotFound) {
+			return OwnerDetails{}, fmt.Errorf("%w: user not found", ErrInvalidInput)
+		}
+		if err != nil {
+			return OwnerDetails{}, err
+		}
+		if user.Role != auth.RoleOwner {
+			return OwnerDetails{}, fmt.Errorf("%w: user must have the owner role", ErrInvalidInput)
+		}
+	}
+	return details, nil
+}
+
+func applyOwnerDetails(o *model.Owner, details OwnerDetails) {
+	o.UserID = details.UserID
+	o.Name = details.Name
+	o.Email = details.Email
+	o.Phone = details.Phone
+	o.Address = details.Address
+	o.ManagementFeePercent = details.ManagementFeePercent
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// preparePage fills in the page size and sort field a listing uses when the
+// request leaves them out.
+func preparePage(page model.PageRequest, defaultSort string) (model.PageRequest, error) {
+	if page.Limit == 0 {
+		page.Limit = defaultPageSize
+	}
+	if page.Limit < 0 || page.Limit > maxPageSize {
+		return model.PageRequest{}, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidInput, maxPageSize)
+	}
+	if page.Sort == "" {
+		page.Sort = defaultSort
+	}
+	return page, nil
+}
+
+// pageError reports a sort field or cursor the store rejected as invalid
+// input.
+func pageError(err error, page model.PageRequest) error {
+	if errors.Is(err, store.ErrInvalidSort) {
+		return fmt.Errorf("%w: cannot sort by %q", ErrInvalidInput, page.Sort)
+	}
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return fmt.Errorf("%w: cursor is invalid or was issued for a different sort", ErrInvalidInput)
+	}
+	return err
+}
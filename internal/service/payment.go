@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrPaymentNotFound = errors.New("payment not found")
+)
+
+type PaymentService struct {
+	paymentStore *store.PaymentStore
+	chargeStore  *store.ChargeStore
+	leaseStore   *store.LeaseStore
+}
+
+func NewPaymentService(ps *store.PaymentStore, cs *store.ChargeStore, ls *store.LeaseStore) *PaymentService {
+	return &PaymentService{
+		paymentStore: ps,
+		chargeStore:  cs,
+		leaseStore:   ls,
+	}
+}
+
+func (s *PaymentService) GetByLeaseID(ctx context.Context, leaseID string) ([]model.Payment, error) {
+	if _, err := s.getLease(ctx, leaseID); err != nil {
+		return nil, err
+	}
+	return s.paymentStore.GetByLeaseID(ctx, leaseID)
+}
+
+func (s *PaymentService) Create(ctx context.Context, leaseID string, amount float64, paidAt time.Time, method, reference string) (model.Payment, error) {
+	if _, err := s.getLease(ctx, leaseID); err != nil {
+		return model.Payment{}, err
+	}
+
+	if amount <= 0 {
+		return model.Payment{}, fmt.Errorf("%w: amount must be positive", ErrInvalidInput)
+	}
+	if !isValidPaymentMethod(method) {
+		return model.Payment{}, fmt.Errorf("%w: method must be 'cash', 'check', 'bank_transfer', 'card' or 'other'", ErrInvalidInput)
+	}
+
+	payment := model.Payment{
+		ID:        generateID(),
+		LeaseID:   leaseID,
+		Amount:    roundCents(amount),
+		PaidAt:    dateOnly(paidAt),
+		Method:    method,
+		Reference: reference,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	return s.paymentStore.Create(ctx, payment)
+}
+
+func (s *PaymentService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	err := s.paymentStore.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrPaymentNotFound
+	}
+	return err
+}
+
+// Ledger builds the running balance of a lease as of the given date. Rent
+// charges are generated from the lease date range; other charges such as
+// late fees and payments are read from their tables.
+func (s *PaymentService) Ledger(ctx context.Context, leaseID string, asOf time.Time) (model.Ledger, error) {
+	lease, err := s.getLease(ctx, leaseID)
+	if err != nil {
+		return model.Ledger{}, err
+	}
+
+	payments, err := s.paymentStore.GetByLeaseID(ctx, leaseID)
+	if err != nil {
+		return model.Ledger{}, err
+	}
+
+	charges, err := s.chargeStore.GetByLeaseID(ctx, leaseID)
+	if err != nil {
+		return model.Ledger{}, err
+	}
+
+	asOf = dateOnly(asOf)
+	var entries []model.LedgerEntry
+	for _, p := range rentPeriods(lease, asOf) {
+		entries = append(entries, model.LedgerEntry{
+			Date:        p.Start,
+			Type:        "rent",
+			Description: fmt.Sprintf("Rent %s to %s", p.Start.Format("2006-01-02"), p.End.Format("2006-01-02")),
+			Amount:      p.Amount,
+		})
+	}
+	for _, c := range charges {
+		if c.ChargeDate.After(asOf) {
+			continue
+		}
+		entries = append(entries, model.LedgerEntry{
+			Date:        c.ChargeDate,
+			Type:        c.Type,
+			Description: c.Description,
+			Amount:      c.Amount,
+		})
+	}
+	for _, p := range payments {
+		if p.PaidAt.After(asOf) {
+			continue
+		}
+		entries = append(entries, model.LedgerEntry{
+			Date:        p.PaidAt,
+			Type:        "payment",
+			Description: fmt.Sprintf("Payment (%s)", p.Method),
+			Amount:      -p.Amount,
+			PaymentID:   p.ID,
+		})
+	}
+
+	// Charges sort before payments on the same day so the balance never
+	// dips negative just because rent was paid on its due date.
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.Before(entries[j].Date)
+		}
+		return entries[i].Type != "payment" && entries[j].Type == "payment"
+	})
+
+	ledger := model.Ledger{
+		LeaseID: leaseID,
+		AsOf:    asOf,
+		Entries: []model.LedgerEntry{},
+	}
+	for _, e := range entries {
+		if e.Amount >= 0 {
+			ledger.TotalCharged = roundCents(ledger.TotalCharged + e.Amount)
+		} else {
+			ledger.TotalPaid = roundCents(ledger.TotalPaid - e.Amount)
+		}
+		ledger.Balance = roundCents(ledger.Balance + e.Amount)
+		e.Balance = ledger.Balance
+		ledger.Entries = append(ledger.Entries, e)
+	}
+
+	return ledger, nil
+}
+
+func (s *PaymentService) getLease(ctx context.Context, leaseID string) (model.Lease, error) {
+	lease, err := s.leaseStore.GetByID(ctx, leaseID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Lease{}, ErrLeaseNotFound
+	}
+	return lease, err
+}
+
+func isValidPaymentMethod(method string) bool {
+	switch method {
+	case "cash", "check", "bank_transfer", "card", "other":
+		return true
+	}
+	return false
+}
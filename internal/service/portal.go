@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+// PortalService is the tenant-facing side of the API. Every method acts for
+// the signed-in tenant and only reaches their own leases and what hangs off
+// them. Financial data is only ever read here; payments are recorded by
+// staff.
+type PortalService struct {
+	leases      *LeaseService
+	tenants     *TenantService
+	payments    *PaymentService
+	invoices    *InvoiceService
+	maintenance *MaintenanceService
+	documents   *store.DocumentStore
+}
+
+func NewPortalService(leases *LeaseService, tenants *TenantService, payments *PaymentService, invoices *InvoiceService, maintenance *MaintenanceService, ds *store.DocumentStore) *PortalService {
+	return &PortalService{
+		leases:      leases,
+		tenants:     tenants,
+		payments:    payments,
+		invoices:    invoices,
+		maintenance: maintenance,
+		documents:   ds,
+	}
+}
+
+// Profile returns the tenant record of the signed-in tenant.
+func (s *PortalService) Profile(ctx context.Context) (model.Tenant, error) {
+	tenantID, err := portalTenant(ctx)
+	if err != nil {
+		return model.Tenant{}, err
+	}
+	return s.tenants.GetByID(ctx, tenantID)
+}
+
+// Leases returns every lease the tenant is a party to, newest first.
+func (s *PortalService) Leases(ctx context.Context) ([]model.Lease, error) {
+	tenantID, err := portalTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.leases.GetByTenantID(ctx, tenantID)
+}
+
+// Lease returns one of the tenant's leases, or their current lease if
+// leaseID is empty: the active one, else the next upcoming one, else the
+// one that ended last.
+func (s *PortalService) Lease(ctx context.Context, leaseID string) (model.Lease, error) {
+	leases, err := s.Leases(ctx)
+	if err != nil {
+		return model.Lease{}, err
+	}
+
+	if leaseID != "" {
+		for _, l := range leases {
+			if l.ID == leaseID {
+				return l, nil
+			}
+		}
+		return model.Lease{}, ErrLeaseNotFound
+	}
+
+	return currentLease(leases)
+}
+
+func (s *PortalService) Payments(ctx context.Context, leaseID string) ([]model.Payment, error) {
+	lease, err := s.Lease(ctx, leaseID)
+	if err != nil {
+		return nil, err
+	}
+	return s.payments.GetByLeaseID(ctx, lease.ID)
+}
+
+func (s *PortalService) Invoices(ctx context.Context, leaseID string) ([]model.Invoice, error) {
+	lease, err := s.Lease(ctx, leaseID)
+	if err != nil {
+		return nil, err
+	}
+	return s.invoices.GetByLeaseID(ctx, lease.ID)
+}
+
+// Ledger returns the running balance of a lease, which is what tenants most
+// often ask about.
+func (s *PortalService) Ledger(ctx context.Context, leaseID string, asOf time.Time) (model.Ledger, error) {
+	lease, err := s.Lease(ctx, leaseID)
+	if err != nil {
+		return model.Ledger{}, err
+	}
+	return s.payments.Ledger(ctx, lease.ID, asOf)
+}
+
+// Documents returns the documents of all the tenant's leases.
+func (s *PortalService) Documents(ctx context.Context) ([]model.Document, error) {
+	leases, err := s.Leases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(leases))
+	for i, l := range leases {
+		ids[i] = l.ID
+	}
+	return s.documents.GetByLeaseIDs(ctx, ids)
+}
+
+// Requests returns the maintenance requests the tenant reported.
+func (s *PortalService) Requests(ctx context.Context) ([]model.MaintenanceRequest, error) {
+	tenantID, err := portalTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.maintenance.GetByTenantID(ctx, tenantID)
+}
+
+func (s *PortalService) Request(ctx context.Context, id string) (model.MaintenanceRequest, error) {
+	tenantID, err := portalTenant(ctx)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+
+	request, err := s.maintenance.GetByID(ctx, id)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+	if request.TenantID == nil || *request.TenantID != tenantID {
+		return model.MaintenanceRequest{}, ErrMaintenanceRequestNotFound
+	}
+	return request, nil
+}
+
+// SubmitRequest reports an issue at the property of the tenant's current
+// lease.
+func (s *PortalService) SubmitRequest(ctx context.Context, title, description string) (model.MaintenanceRequest, error) {
+	tenantID, err := portalTenant(ctx)
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+
+	lease, err := s.Lease(ctx, "")
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+	return s.maintenance.Report(ctx, lease, tenantID, title, description)
+}
+
+// portalTenant returns the tenant record the signed-in user is, or
+// ErrForbidden if they are not a tenant.
+func portalTenant(ctx context.Context) (string, error) {
+	if err := authorize(ctx, auth.PermPortal); err != nil {
+		return "", err
+	}
+
+	tenantID := auth.ScopeFrom(ctx).TenantID
+	if tenantID == "" {
+		return "", ErrForbidden
+	}
+	return tenantID, nil
+}
+
+// currentLease picks the lease a tenant most likely means from leases
+// ordered newest first.
+func currentLease(leases []model.Lease) (model.Lease, error) {
+	var upcoming *model.Lease
+	for i, l := range leases {
+		if l.Status == "active" {
+			return l, nil
+		}
+		if l.Status == "upcoming" {
+			upcoming = &leases[i]
+		}
+	}
+
+	if upcoming != nil {
+		return *upcoming, nil
+	}
+	if len(leases) > 0 {
+		return leases[0], nil
+	}
+	return model.Lease{}, ErrLeaseNotFound
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/Lacsw/rntly/internal/auth"
 	"github.com/Lacsw/rntly/internal/model"
 	"github.com/Lacsw/rntly/internal/store"
 )
@@ -13,48 +16,112 @@ import (
 var (
 	ErrPropertyNotFound = errors.New("property not found")
 	ErrInvalidInput     = errors.New("invalid input")
+	ErrDuplicateUnit    = errors.New("unit number already exists in this building")
 )
 
 type PropertyService struct {
-	store *store.PropertyStore
+	store     *store.PropertyStore
+	buildings *store.BuildingStore
+	owners    *store.OwnerStore
 }
 
-func NewPropertyService(s *store.PropertyStore) *PropertyService {
-	return &PropertyService{store: s}
+func NewPropertyService(s *store.PropertyStore, bs *store.BuildingStore, os *store.OwnerStore) *PropertyService {
+	return &PropertyService{store: s, buildings: bs, owners: os}
 }
 
-func (s *PropertyService) List(ctx context.Context) ([]model.Property, error) {
-	return s.store.GetAll(ctx)
+// List returns one page of the properties matching the filter with their
+// occupancy as of the given date.
+func (s *PropertyService) List(ctx context.Context, asOf time.Time, filter model.PropertyFilter, page model.PageRequest) (model.Page[model.Property], error) {
+	if err := authorize(ctx, auth.PermPropertiesRead); err != nil {
+		return model.Page[model.Property]{}, err
+	}
+
+	filter.City = collapseSpaces(filter.City)
+	filter.PostalCode = strings.ToUpper(collapseSpaces(filter.PostalCode))
+	if filter.Status != "" && filter.Status != "occupied" && filter.Status != "vacant" {
+		return model.Page[model.Property]{}, fmt.Errorf("%w: status must be 'occupied' or 'vacant'", ErrInvalidInput)
+	}
+	if filter.PetPolicy != "" && !isValidPetPolicy(filter.PetPolicy) {
+		return model.Page[model.Property]{}, fmt.Errorf("%w: unknown pet policy %q", ErrInvalidInput, filter.PetPolicy)
+	}
+	if len(filter.Amenities) > 0 {
+		filter.Amenities = normalizeAmenities(filter.Amenities)
+	}
+
+	page, err := preparePage(page, "created_at")
+	if err != nil {
+		return model.Page[model.Property]{}, err
+	}
+
+	properties, err := s.store.List(ctx, asOf, filter, page)
+	return properties, pageError(err, page)
 }
 
-func (s *PropertyService) GetByID(ctx context.Context, id string) (model.Property, error) {
-	property, err := s.store.GetByID(ctx, id)
+func (s *PropertyService) GetByID(ctx context.Context, id string, asOf time.Time) (model.Property, error) {
+	if err := authorize(ctx, auth.PermPropertiesRead); err != nil {
+		return model.Property{}, err
+	}
+
+	property, err := s.store.GetByIDAsOf(ctx, id, asOf)
 	if errors.Is(err, store.ErrNotFound) {
 		return model.Property{}, ErrPropertyNotFound
 	}
 	return property, err
 }
 
-func (s *PropertyService) Create(ctx context.Context, address, propertyType string, bedrooms int, rentAmount float64) (model.Property, error) {
-	if err := s.validateInput(address, propertyType, bedrooms, rentAmount); err != nil {
+// PropertyDetails are the editable fields of a property. When BuildingID is
+// set the property is a unit of that building; when OwnerID is set it is
+// managed for that owner.
+type PropertyDetails struct {
+	BuildingID    *string
+	UnitNumber    *string
+	OwnerID       *string
+	Address       model.Address
+	Type          string
+	Bedrooms      int
+	Bathrooms     float64
+	Area          *float64
+	Floor         *int
+	Furnished     bool
+	ParkingSpaces int
+	PetPolicy     string
+	Amenities     []string
+	RentAmount    float64
+}
+
+// Create adds a property. A building unit takes the building's address
+// unless one is given.
+func (s *PropertyService) Create(ctx context.Context, details PropertyDetails) (model.Property, error) {
+	if err := authorize(ctx, auth.PermPropertiesWrite); err != nil {
+		return model.Property{}, err
+	}
+
+	details, err := s.prepare(ctx, details)
+	if err != nil {
 		return model.Property{}, err
 	}
 
 	property := model.Property{
-		ID:         generateID(),
-		Address:    address,
-		Type:       propertyType,
-		Bedrooms:   bedrooms,
-		RentAmount: rentAmount,
-		Status:     "vacant",
-		CreatedAt:  time.Now().UTC(),
-		UpdatedAt:  time.Now().UTC(),
+		ID:             generateID(),
+		OrganizationID: organizationID(ctx),
+		Status:         "vacant",
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
 	}
+	applyPropertyDetails(&property, details)
 
-	return s.store.Create(ctx, property)
+	property, err = s.store.Create(ctx, property)
+	if errors.Is(err, store.ErrDuplicate) {
+		return model.Property{}, ErrDuplicateUnit
+	}
+	return property, err
 }
 
-func (s *PropertyService) Update(ctx context.Context, id, address, propertyType string, bedrooms int, rentAmount float64, status string) (model.Property, error) {
+func (s *PropertyService) Update(ctx context.Context, id string, details PropertyDetails) (model.Property, error) {
+	if err := authorize(ctx, auth.PermPropertiesWrite); err != nil {
+		return model.Property{}, err
+	}
+
 	existing, err := s.store.GetByID(ctx, id)
 	if errors.Is(err, store.ErrNotFound) {
 		return model.Property{}, ErrPropertyNotFound
@@ -63,25 +130,26 @@ func (s *PropertyService) Update(ctx context.Context, id, address, propertyType
 		return model.Property{}, err
 	}
 
-	if err := s.validateInput(address, propertyType, bedrooms, rentAmount); err != nil {
+	details, err = s.prepare(ctx, details)
+	if err != nil {
 		return model.Property{}, err
 	}
 
-	if !isValidStatus(status) {
-		return model.Property{}, fmt.Errorf("%w: status must be 'vacant' or 'occupied'", ErrInvalidInput)
-	}
-
-	existing.Address = address
-	existing.Type = propertyType
-	existing.Bedrooms = bedrooms
-	existing.RentAmount = rentAmount
-	existing.Status = status
+	applyPropertyDetails(&existing, details)
 	existing.UpdatedAt = time.Now().UTC()
 
-	return s.store.Update(ctx, existing)
+	updated, err := s.store.Update(ctx, existing)
+	if errors.Is(err, store.ErrDuplicate) {
+		return model.Property{}, ErrDuplicateUnit
+	}
+	return updated, err
 }
 
 func (s *PropertyService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermPropertiesDelete); err != nil {
+		return err
+	}
+
 	err := s.store.Delete(ctx, id)
 	if errors.Is(err, store.ErrNotFound) {
 		return ErrPropertyNotFound
@@ -89,24 +157,116 @@ func (s *PropertyService) Delete(ctx context.Context, id string) error {
 	return err
 }
 
-func (s *PropertyService) validateInput(address, propertyType string, bedrooms int, rentAmount float64) error {
-	if address == "" {
-		return fmt.Errorf("%w: address is required", ErrInvalidInput)
+// resolveBuilding checks the building a unit belongs to and returns the
+// normalized address the property should have. A unit given no address gets
+// the building's, with the unit number as its unit.
+func (s *PropertyService) resolveBuilding(ctx context.Context, buildingID, unitNumber *string, address model.Address) (model.Address, error) {
+	if buildingID == nil {
+		if unitNumber != nil {
+			return model.Address{}, fmt.Errorf("%w: unit number requires a building", ErrInvalidInput)
+		}
+		return normalizeAddress(address), nil
 	}
-	if propertyType == "" {
+
+	if unitNumber == nil || *unitNumber == "" {
+		return model.Address{}, fmt.Errorf("%w: unit number is required for a building unit", ErrInvalidInput)
+	}
+
+	building, err := s.buildings.GetByID(ctx, *buildingID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Address{}, fmt.Errorf("%w: building not found", ErrInvalidInput)
+	}
+	if err != nil {
+		return model.Address{}, err
+	}
+
+	if address.IsZero() {
+		address = building.Address
+		address.Unit = *unitNumber
+	}
+	return normalizeAddress(address), nil
+}
+
+// prepare normalizes the details, filling in defaults and the building
+// address, and validates the result.
+func (s *PropertyService) prepare(ctx context.Context, details PropertyDetails) (PropertyDetails, error) {
+	address, err := s.resolveBuilding(ctx, details.BuildingID, details.UnitNumber, details.Address)
+	if err != nil {
+		return PropertyDetails{}, err
+	}
+	details.Address = address
+	details.Amenities = normalizeAmenities(details.Amenities)
+
+	if details.OwnerID != nil {
+		_, err := s.owners.GetByID(ctx, *details.OwnerID)
+		if errors.Is(err, store.ErrNotFound) {
+			return PropertyDetails{}, fmt.Errorf("%w: owner not found", ErrInvalidInput)
+		}
+		if err != nil {
+			return PropertyDetails{}, err
+		}
+	}
+	if details.PetPolicy == "" {
+		details.PetPolicy = "not_allowed"
+	}
+
+	if err := s.validateInput(details); err != nil {
+		return PropertyDetails{}, err
+	}
+	return details, nil
+}
+
+func (s *PropertyService) validateInput(details PropertyDetails) error {
+	if err := validateAddress(details.Address); err != nil {
+		return err
+	}
+	if details.Type == "" {
 		return fmt.Errorf("%w: type is required", ErrInvalidInput)
 	}
-	if bedrooms < 0 {
+	if details.Bedrooms < 0 {
 		return fmt.Errorf("%w: bedrooms cannot be negative", ErrInvalidInput)
 	}
-	if rentAmount <= 0 {
+	if details.Bathrooms < 0 || math.Mod(details.Bathrooms*2, 1) != 0 {
+		return fmt.Errorf("%w: bathrooms must be a non-negative multiple of 0.5", ErrInvalidInput)
+	}
+	if details.Area != nil && *details.Area <= 0 {
+		return fmt.Errorf("%w: area must be positive", ErrInvalidInput)
+	}
+	if details.ParkingSpaces < 0 {
+		return fmt.Errorf("%w: parking spaces cannot be negative", ErrInvalidInput)
+	}
+	if !isValidPetPolicy(details.PetPolicy) {
+		return fmt.Errorf("%w: pet policy must be 'not_allowed', 'cats_only', 'dogs_only', 'allowed', or 'case_by_case'", ErrInvalidInput)
+	}
+	if details.RentAmount <= 0 {
 		return fmt.Errorf("%w: rent amount must be positive", ErrInvalidInput)
 	}
 	return nil
 }
 
-func isValidStatus(status string) bool {
-	return status == "vacant" || status == "occupied"
+func applyPropertyDetails(p *model.Property, details PropertyDetails) {
+	p.BuildingID = details.BuildingID
+	p.UnitNumber = details.UnitNumber
+	p.OwnerID = details.OwnerID
+	p.Address = details.Address
+	p.Type = details.Type
+	p.Bedrooms = details.Bedrooms
+	p.Bathrooms = details.Bathrooms
+	p.Area = details.Area
+	p.Floor = details.Floor
+	p.Furnished = details.Furnished
+	p.ParkingSpaces = details.ParkingSpaces
+	p.PetPolicy = details.PetPolicy
+	p.Amenities = details.Amenities
+	p.RentAmount = details.RentAmount
+}
+
+func isValidPetPolicy(policy string) bool {
+	switch policy {
+	case "not_allowed", "cats_only", "dogs_only", "allowed", "case_by_case":
+		return true
+	}
+	return false
 }
 
 func generateID() string {
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+// rentPeriod is one monthly billing period of a lease. Start and End are
+// inclusive dates; Amount is prorated when the lease ends mid-period.
+type rentPeriod struct {
+	Start  time.Time
+	End    time.Time
+	Amount float64
+}
+
+// rentPeriods splits a lease into monthly periods anchored on its start date
+// and returns those that have started on or before through.
+func rentPeriods(lease model.Lease, through time.Time) []rentPeriod {
+	start := dateOnly(lease.StartDate)
+	end := dateOnly(lease.EndDate)
+	through = dateOnly(through)
+
+	var periods []rentPeriod
+	for i := 0; ; i++ {
+		periodStart := addMonths(start, i)
+		if periodStart.After(end) || periodStart.After(through) {
+			break
+		}
+
+		fullEnd := addMonths(start, i+1).AddDate(0, 0, -1)
+		periodEnd := fullEnd
+		amount := lease.RentAmount
+		if periodEnd.After(end) {
+			periodEnd = end
+			amount = roundCents(lease.RentAmount * float64(daysBetween(periodStart, periodEnd)+1) / float64(daysBetween(periodStart, fullEnd)+1))
+		}
+
+		periods = append(periods, rentPeriod{Start: periodStart, End: periodEnd, Amount: amount})
+	}
+
+	return periods
+}
+
+// addMonths adds n months to t, clamping the day to the end of the target
+// month so a lease starting on the 31st bills on the 30th/28th instead of
+// spilling into the following month.
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+func dateOnly(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(dateOnly(to).Sub(dateOnly(from)).Hours() / 24)
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	maxSearchTerms     = 10
+)
+
+var searchTypes = []string{"tenant", "property", "lease"}
+
+type SearchService struct {
+	store *store.SearchStore
+}
+
+func NewSearchService(s *store.SearchStore) *SearchService {
+	return &SearchService{store: s}
+}
+
+// Search finds tenants, properties and leases matching every word of q.
+// Each word matches as a prefix, so partially typed input already finds
+// results. types limits the entity types searched; empty means all.
+func (s *SearchService) Search(ctx context.Context, q string, types []string, limit int) ([]model.SearchResult, error) {
+	tsquery := prefixQuery(q)
+	if tsquery == "" {
+		return nil, fmt.Errorf("%w: search query must contain a letter or digit", ErrInvalidInput)
+	}
+
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit < 0 || limit > maxSearchLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidInput, maxSearchLimit)
+	}
+
+	if len(types) == 0 {
+		types = searchTypes
+	}
+	for _, t := range types {
+		if !isValidSearchType(t) {
+			return nil, fmt.Errorf("%w: type must be 'tenant', 'property', or 'lease'", ErrInvalidInput)
+		}
+	}
+
+	return s.store.Search(ctx, tsquery, types, limit)
+}
+
+// prefixQuery turns free text into a tsquery requiring every word as a
+// prefix. Punctuation separates words and is otherwise dropped, so the
+// result is always a well-formed query.
+func prefixQuery(q string) string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = w + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+func isValidSearchType(t string) bool {
+	for _, st := range searchTypes {
+		if t == st {
+			return true
+		}
+	}
+	return false
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Lacsw/rntly/internal/auth"
 	"github.com/Lacsw/rntly/internal/model"
 	"github.com/Lacsw/rntly/internal/store"
 )
 
 var (
 	ErrTenantNotFound = errors.New("tenant not found")
+	ErrTenantOnLease  = errors.New("tenant is still a party to a lease")
 )
 
 type TenantService struct {
@@ -22,11 +25,30 @@ func NewTenantService(s *store.TenantStore) *TenantService {
 	return &TenantService{store: s}
 }
 
-func (s *TenantService) List(ctx context.Context) ([]model.Tenant, error) {
-	return s.store.GetAll(ctx)
+// List returns one page of the tenants matching the filter.
+func (s *TenantService) List(ctx context.Context, filter model.TenantFilter, page model.PageRequest) (model.Page[model.Tenant], error) {
+	if err := authorize(ctx, auth.PermTenantsRead); err != nil {
+		return model.Page[model.Tenant]{}, err
+	}
+
+	filter.Name = collapseSpaces(filter.Name)
+	filter.City = collapseSpaces(filter.City)
+	filter.PostalCode = strings.ToUpper(collapseSpaces(filter.PostalCode))
+
+	page, err := preparePage(page, "created_at")
+	if err != nil {
+		return model.Page[model.Tenant]{}, err
+	}
+
+	tenants, err := s.store.List(ctx, filter, page)
+	return tenants, pageError(err, page)
 }
 
 func (s *TenantService) GetByID(ctx context.Context, id string) (model.Tenant, error) {
+	if err := authorize(ctx, auth.PermTenantsRead); err != nil {
+		return model.Tenant{}, err
+	}
+
 	tenant, err := s.store.GetByID(ctx, id)
 	if errors.Is(err, store.ErrNotFound) {
 		return model.Tenant{}, ErrTenantNotFound
@@ -34,25 +56,38 @@ func (s *TenantService) GetByID(ctx context.Context, id string) (model.Tenant, e
 	return tenant, err
 }
 
-func (s *TenantService) Create(ctx context.Context, firstName, lastName, email, phone string) (model.Tenant, error) {
-	if err := s.validateInput(firstName, lastName, email); err != nil {
+// Create adds a tenant. The mailing address is optional, but if any part of
+// it is given it must be complete.
+func (s *TenantService) Create(ctx context.Context, firstName, lastName, email, phone string, address model.Address) (model.Tenant, error) {
+	if err := authorize(ctx, auth.PermTenantsWrite); err != nil {
+		return model.Tenant{}, err
+	}
+
+	address = normalizeAddress(address)
+	if err := s.validateInput(firstName, lastName, email, address); err != nil {
 		return model.Tenant{}, err
 	}
 
 	tenant := model.Tenant{
-		ID:        generateID(),
-		FirstName: firstName,
-		LastName:  lastName,
-		Email:     email,
-		Phone:     phone,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
+		ID:             generateID(),
+		OrganizationID: organizationID(ctx),
+		FirstName:      firstName,
+		LastName:       lastName,
+		Email:          email,
+		Phone:          phone,
+		Address:        address,
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
 	}
 
 	return s.store.Create(ctx, tenant)
 }
 
-func (s *TenantService) Update(ctx context.Context, id, firstName, lastName, email, phone string) (model.Tenant, error) {
+func (s *TenantService) Update(ctx context.Context, id, firstName, lastName, email, phone string, address model.Address) (model.Tenant, error) {
+	if err := authorize(ctx, auth.PermTenantsWrite); err != nil {
+		return model.Tenant{}, err
+	}
+
 	existing, err := s.store.GetByID(ctx, id)
 	if errors.Is(err, store.ErrNotFound) {
 		return model.Tenant{}, ErrTenantNotFound
@@ -61,7 +96,8 @@ func (s *TenantService) Update(ctx context.Context, id, firstName, lastName, ema
 		return model.Tenant{}, err
 	}
 
-	if err := s.validateInput(firstName, lastName, email); err != nil {
+	address = normalizeAddress(address)
+	if err := s.validateInput(firstName, lastName, email, address); err != nil {
 		return model.Tenant{}, err
 	}
 
@@ -69,20 +105,28 @@ func (s *TenantService) Update(ctx context.Context, id, firstName, lastName, ema
 	existing.LastName = lastName
 	existing.Email = email
 	existing.Phone = phone
+	existing.Address = address
 	existing.UpdatedAt = time.Now().UTC()
 
 	return s.store.Update(ctx, existing)
 }
 
 func (s *TenantService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermTenantsDelete); err != nil {
+		return err
+	}
+
 	err := s.store.Delete(ctx, id)
 	if errors.Is(err, store.ErrNotFound) {
 		return ErrTenantNotFound
 	}
+	if errors.Is(err, store.ErrInUse) {
+		return ErrTenantOnLease
+	}
 	return err
 }
 
-func (s *TenantService) validateInput(firstName, lastName, email string) error {
+func (s *TenantService) validateInput(firstName, lastName, email string, address model.Address) error {
 	if firstName == "" {
 		return fmt.Errorf("%w: first name is required", ErrInvalidInput)
 	}
@@ -92,5 +136,8 @@ func (s *TenantService) validateInput(firstName, lastName, email string) error {
 	if email == "" {
 		return fmt.Errorf("%w: email is required", ErrInvalidInput)
 	}
+	if !address.IsZero() {
+		return validateAddress(address)
+	}
 	return nil
 }
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+const minPasswordLength = 8
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrEmailTaken   = errors.New("a user with this email already exists")
+	ErrDeleteSelf   = errors.New("users cannot delete their own account")
+)
+
+type UserService struct {
+	store       *store.UserStore
+	tenantStore *store.TenantStore
+}
+
+func NewUserService(s *store.UserStore, ts *store.TenantStore) *UserService {
+	return &UserService{store: s, tenantStore: ts}
+}
+
+func (s *UserService) List(ctx context.Context) ([]model.User, error) {
+	if err := authorize(ctx, auth.PermUsersManage); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetAll(ctx)
+}
+
+func (s *UserService) GetByID(ctx context.Context, id string) (model.User, error) {
+	if err := authorize(ctx, auth.PermUsersManage); err != nil {
+		return model.User{}, err
+	}
+
+	user, err := s.store.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.User{}, ErrUserNotFound
+	}
+	return user, err
+}
+
+// Create adds an account. A user with the tenant role must be linked to the
+// tenant record they are, and only they may be.
+func (s *UserService) Create(ctx context.Context, email, name, password, role string, tenantID *string) (model.User, error) {
+	if err := authorize(ctx, auth.PermUsersManage); err != nil {
+		return model.User{}, err
+	}
+	if err := s.validateRole(ctx, role, tenantID); err != nil {
+		return model.User{}, err
+	}
+
+	return s.create(ctx, model.User{
+		OrganizationID: organizationID(ctx),
+		Email:          email,
+		Name:           name,
+		Role:           role,
+		TenantID:       tenantID,
+	}, password)
+}
+
+// create validates and stores a new account in the user's organization.
+// Its ID, password hash and timestamps are assigned here.
+func (s *UserService) create(ctx context.Context, user model.User, password string) (model.User, error) {
+	user.Email = strings.TrimSpace(user.Email)
+	if _, err := mail.ParseAddress(user.Email); err != nil {
+		return model.User{}, fmt.Errorf("%w: email is not valid", ErrInvalidInput)
+	}
+	if len(password) < minPasswordLength {
+		return model.User{}, fmt.Errorf("%w: password must be at least %d characters", ErrInvalidInput, minPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return model.User{}, fmt.Errorf("%w: password must be at most 72 bytes", ErrInvalidInput)
+	}
+	if err != nil {
+		return model.User{}, err
+	}
+
+	user.ID = generateID()
+	user.Name = strings.TrimSpace(user.Name)
+	user.PasswordHash = string(hash)
+	user.CreatedAt = time.Now().UTC()
+	user.UpdatedAt = time.Now().UTC()
+
+	user, err = s.store.Create(ctx, user)
+	if errors.Is(err, store.ErrDuplicate) {
+		return model.User{}, ErrEmailTaken
+	}
+	return user, err
+}
+
+// Update changes a user's name, role and tenant link.
+func (s *UserService) Update(ctx context.Context, id, name, role string, tenantID *string) (model.User, error) {
+	if err := authorize(ctx, auth.PermUsersManage); err != nil {
+		return model.User{}, err
+	}
+
+	existing, err := s.store.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return model.User{}, err
+	}
+
+	if err := s.validateRole(ctx, role, tenantID); err != nil {
+		return model.User{}, err
+	}
+
+	existing.Name = strings.TrimSpace(name)
+	existing.Role = role
+	existing.TenantID = tenantID
+	existing.UpdatedAt = time.Now().UTC()
+
+	return s.store.Update(ctx, existing)
+}
+
+// Delete removes a user and, with it, all of their sessions. currentUserID
+// is the user making the request, who may not delete themselves.
+func (s *UserService) Delete(ctx context.Context, currentUserID, id string) error {
+	if err := authorize(ctx, auth.PermUsersManage); err != nil {
+		return err
+	}
+
+	if id == currentUserID {
+		return ErrDeleteSelf
+	}
+
+	err := s.store.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// EnsureAdmin creates the first account from the given credentials when no
+// user exists yet, so a fresh install can be signed in to. The account is
+// staff of the default organization. It does nothing once any user exists
+// or when email is empty.
+func (s *UserService) EnsureAdmin(ctx context.Context, email, password string) error {
+	if email == "" {
+		return nil
+	}
+
+	n, err := s.store.Count(ctx)
+	if err != nil || n > 0 {
+		return err
+	}
+
+	if _, err := s.createAdmin(ctx, model.DefaultOrganizationID, email, "Administrator", password); err != nil {
+		return err
+	}
+	log.Printf("created initial user %s", email)
+	return nil
+}
+
+// createAdmin creates a staff account in the given organization.
+func (s *UserService) createAdmin(ctx context.Context, organizationID, email, name, password string) (model.User, error) {
+	return s.create(ctx, model.User{
+		OrganizationID: organizationID,
+		Email:          email,
+		Name:           name,
+		Role:           auth.RoleStaff,
+	}, password)
+}
+
+func (s *UserService) validateRole(ctx context.Context, role string, tenantID *string) error {
+	if !auth.IsValidRole(role) {
+		return fmt.Errorf("%w: role must be 'staff', 'agent', 'owner', or 'tenant'", ErrInvalidInput)
+	}
+
+	if role != auth.RoleTenant {
+		if tenantID != nil {
+			return fmt.Errorf("%w: only tenant users can be linked to a tenant", ErrInvalidInput)
+		}
+		return nil
+	}
+
+	if tenantID == nil {
+		return fmt.Errorf("%w: tenant users must be linked to a tenant", ErrInvalidInput)
+	}
+	_, err := s.tenantStore.GetByID(ctx, *tenantID)
+	if errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("%w: tenant not found", ErrInvalidInput)
+	}
+	return err
+}
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/auth"
+	"github.com/Lacsw/rntly/internal/model"
+	"github.com/Lacsw/rntly/internal/store"
+)
+
+var (
+	ErrVendorNotFound        = errors.New("vendor not found")
+	ErrVendorJobNotFound     = errors.New("job not found")
+	ErrVendorInvoiceNotFound = errors.New("vendor invoice not found")
+	ErrVendorInvoicePaid     = errors.New("vendor invoice is already paid")
+	ErrVendorInsuranceLapsed = errors.New("vendor's insurance has lapsed")
+)
+
+// VendorDetails are the editable fields of a vendor. Trade defaults to
+// general.
+type VendorDetails struct {
+	Name               string
+	Trade              string
+	ContactName        string
+	Email              string
+	Phone              string
+	InsuranceExpiresOn *time.Time
+	HourlyRate         *float64
+}
+
+// JobDetails describe work a vendor did. MaintenanceRequestID, if set,
+// must be a request at the same property.
+type JobDetails struct {
+	PropertyID           string
+	MaintenanceRequestID *string
+	Description          string
+	PerformedOn          time.Time
+	Hours                *float64
+}
+
+// InvoiceDetails describe a bill from a vendor. JobID, if set, must be one
+// of the vendor's jobs.
+type InvoiceDetails struct {
+	JobID    *string
+	Number   string
+	IssuedOn time.Time
+	DueOn    *time.Time
+	Amount   float64
+}
+
+type VendorService struct {
+	store            *store.VendorStore
+	propertyStore    *store.PropertyStore
+	maintenanceStore *store.MaintenanceStore
+}
+
+func NewVendorService(s *store.VendorStore, ps *store.PropertyStore, ms *store.MaintenanceStore) *VendorService {
+	return &VendorService{
+		store:            s,
+		propertyStore:    ps,
+		maintenanceStore: ms,
+	}
+}
+
+func (s *VendorService) List(ctx context.Context, filter model.VendorFilter) ([]model.Vendor, error) {
+	if filter.Trade != "" && !isValidTrade(filter.Trade) {
+		return nil, fmt.Errorf("%w: unknown trade %q", ErrInvalidInput, filter.Trade)
+	}
+
+	today := dateOnly(time.Now().UTC())
+	vendors, err := s.store.GetAll(ctx, filter, today)
+	if err != nil {
+		return nil, err
+	}
+	for i := range vendors {
+		vendors[i].InsuranceLapsed = insuranceLapsed(vendors[i], today)
+	}
+	return vendors, nil
+}
+
+func (s *VendorService) GetByID(ctx context.Context, id string) (model.Vendor, error) {
+	vendor, err := s.store.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.Vendor{}, ErrVendorNotFound
+	}
+	if err != nil {
+		return model.Vendor{}, err
+	}
+
+	vendor.InsuranceLapsed = insuranceLapsed(vendor, dateOnly(time.Now().UTC()))
+	return vendor, nil
+}
+
+// GetByPropertyID returns the vendors that have done jobs at the property.
+func (s *VendorService) GetByPropertyID(ctx context.Context, propertyID string) ([]model.PropertyVendor, error) {
+	if err := s.checkProperty(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	vendors, err := s.store.GetByPropertyID(ctx, propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := dateOnly(time.Now().UTC())
+	for i := range vendors {
+		vendors[i].InsuranceLapsed = insuranceLapsed(vendors[i].Vendor, today)
+	}
+	return vendors, nil
+}
+
+func (s *VendorService) Create(ctx context.Context, details VendorDetails) (model.Vendor, error) {
+	details, err := prepareVendor(details)
+	if err != nil {
+		return model.Vendor{}, err
+	}
+
+	vendor := model.Vendor{
+		ID:             generateID(),
+		OrganizationID: organizationID(ctx),
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+	applyVendorDetails(&vendor, details)
+
+	vendor, err = s.store.Create(ctx, vendor)
+	if err != nil {
+		return model.Vendor{}, err
+	}
+
+	vendor.InsuranceLapsed = insuranceLapsed(vendor, dateOnly(time.Now().UTC()))
+	return vendor, nil
+}
+
+func (s *VendorService) Update(ctx context.Context, id string, details VendorDetails) (model.Vendor, error) {
+	vendor, err := s.GetByID(ctx, id)
+	if err != nil {
+		return model.Vendor{}, err
+	}
+
+	details, err = prepareVendor(details)
+	if err != nil {
+		return model.Vendor{}, err
+	}
+
+	applyVendorDetails(&vendor, details)
+	vendor.UpdatedAt = time.Now().UTC()
+
+	vendor, err = s.store.Update(ctx, vendor)
+	if err != nil {
+		return model.Vendor{}, err
+	}
+
+	vendor.InsuranceLapsed = insuranceLapsed(vendor, dateOnly(time.Now().UTC()))
+	return vendor, nil
+}
+
+// Delete removes a vendor along with their jobs and invoices. Requests
+// assigned to them keep their status but lose the assignment.
+func (s *VendorService) Delete(ctx context.Context, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	err := s.store.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrVendorNotFound
+	}
+	return err
+}
+
+func (s *VendorService) Jobs(ctx context.Context, vendorID string) ([]model.VendorJob, error) {
+	if _, err := s.GetByID(ctx, vendorID); err != nil {
+		return nil, err
+	}
+	return s.store.GetJobs(ctx, vendorID)
+}
+
+// PropertyJobs returns the jobs any vendor did at the property.
+func (s *VendorService) PropertyJobs(ctx context.Context, propertyID string) ([]model.VendorJob, error) {
+	if err := s.checkProperty(ctx, propertyID); err != nil {
+		return nil, err
+	}
+	return s.store.GetJobsByPropertyID(ctx, propertyID)
+}
+
+// RecordJob adds a job to the vendor's history. The description defaults
+// to the title of the maintenance request the job was for.
+func (s *VendorService) RecordJob(ctx context.Context, vendorID string, details JobDetails) (model.VendorJob, error) {
+	if _, err := s.GetByID(ctx, vendorID); err != nil {
+		return model.VendorJob{}, err
+	}
+
+	_, err := s.propertyStore.GetByID(ctx, details.PropertyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.VendorJob{}, fmt.Errorf("%w: property not found", ErrInvalidInput)
+	}
+	if err != nil {
+		return model.VendorJob{}, err
+	}
+
+	details.Description = strings.TrimSpace(details.Description)
+	if details.MaintenanceRequestID != nil {
+		request, err := s.maintenanceStore.GetByID(ctx, *details.MaintenanceRequestID)
+		if errors.Is(err, store.ErrNotFound) || (err == nil && request.PropertyID != details.PropertyID) {
+			return model.VendorJob{}, fmt.Errorf("%w: maintenance request not found at this property", ErrInvalidInput)
+		}
+		if err != nil {
+			return model.VendorJob{}, err
+		}
+		if details.Description == "" {
+			details.Description = request.Title
+		}
+	}
+
+	if details.Description == "" {
+		return model.VendorJob{}, fmt.Errorf("%w: description is required", ErrInvalidInput)
+	}
+	if details.Hours != nil && *details.Hours <= 0 {
+		return model.VendorJob{}, fmt.Errorf("%w: hours must be positive", ErrInvalidInput)
+	}
+
+	job := model.VendorJob{
+		ID:                   generateID(),
+		VendorID:             vendorID,
+		PropertyID:           details.PropertyID,
+		MaintenanceRequestID: details.MaintenanceRequestID,
+		Description:          details.Description,
+		PerformedOn:          dateOnly(details.PerformedOn),
+		Hours:                details.Hours,
+		CreatedAt:            time.Now().UTC(),
+	}
+
+	return s.store.CreateJob(ctx, job)
+}
+
+func (s *VendorService) DeleteJob(ctx context.Context, vendorID, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if _, err := s.GetByID(ctx, vendorID); err != nil {
+		return err
+	}
+
+	err := s.store.DeleteJob(ctx, vendorID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrVendorJobNotFound
+	}
+	return err
+}
+
+func (s *VendorService) Invoices(ctx context.Context, vendorID string) ([]model.VendorInvoice, error) {
+	if _, err := s.GetByID(ctx, vendorID); err != nil {
+		return nil, err
+	}
+	return s.store.GetInvoices(ctx, vendorID)
+}
+
+func (s *VendorService) AddInvoice(ctx context.Context, vendorID string, details InvoiceDetails) (model.VendorInvoice, error) {
+	if _, err := s.GetByID(ctx, vendorID); err != nil {
+		return model.VendorInvoice{}, err
+	}
+
+	if details.JobID != nil {
+		_, err := s.store.GetJob(ctx, vendorID, *details.JobID)
+		if errors.Is(err, store.ErrNotFound) {
+			return model.VendorInvoice{}, fmt.Errorf("%w: job not found for this vendor", ErrInvalidInput)
+		}
+		if err != nil {
+			return model.VendorInvoice{}, err
+		}
+	}
+
+	if details.Amount <= 0 {
+		return model.VendorInvoice{}, fmt.Errorf("%w: amount must be positive", ErrInvalidInput)
+	}
+	if details.DueOn != nil && details.DueOn.Before(details.IssuedOn) {
+		return model.VendorInvoice{}, fmt.Errorf("%w: due date cannot be before the issue date", ErrInvalidInput)
+	}
+
+	invoice := model.VendorInvoice{
+		ID:        generateID(),
+		VendorID:  vendorID,
+		JobID:     details.JobID,
+		Number:    strings.TrimSpace(details.Number),
+		IssuedOn:  dateOnly(details.IssuedOn),
+		Amount:    roundCents(details.Amount),
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if details.DueOn != nil {
+		dueOn := dateOnly(*details.DueOn)
+		invoice.DueOn = &dueOn
+	}
+
+	return s.store.CreateInvoice(ctx, invoice)
+}
+
+// PayInvoice records that the invoice was paid on the given date.
+func (s *VendorService) PayInvoice(ctx context.Context, vendorID, id string, paidOn time.Time) (model.VendorInvoice, error) {
+	if _, err := s.GetByID(ctx, vendorID); err != nil {
+		return model.VendorInvoice{}, err
+	}
+
+	invoice, err := s.store.GetInvoice(ctx, vendorID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.VendorInvoice{}, ErrVendorInvoiceNotFound
+	}
+	if err != nil {
+		return model.VendorInvoice{}, err
+	}
+	if invoice.PaidOn != nil {
+		return model.VendorInvoice{}, ErrVendorInvoicePaid
+	}
+
+	paidOn = dateOnly(paidOn)
+	invoice.PaidOn = &paidOn
+	invoice.UpdatedAt = time.Now().UTC()
+
+	return s.store.UpdateInvoice(ctx, invoice)
+}
+
+func (s *VendorService) DeleteInvoice(ctx context.Context, vendorID, id string) error {
+	if err := authorize(ctx, auth.PermOperationsDelete); err != nil {
+		return err
+	}
+
+	if _, err := s.GetByID(ctx, vendorID); err != nil {
+		return err
+	}
+
+	err := s.store.DeleteInvoice(ctx, vendorID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrVendorInvoiceNotFound
+	}
+	return err
+}
+
+func (s *VendorService) checkProperty(ctx context.Context, propertyID string) error {
+	_, err := s.propertyStore.GetByID(ctx, propertyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrPropertyNotFound
+	}
+	return err
+}
+
+// insuranceLapsed reports whether the vendor's insurance expired before
+// the given day.
+func insuranceLapsed(v model.Vendor, day time.Time) bool {
+	return v.InsuranceExpiresOn != nil && v.InsuranceExpiresOn.Before(day)
+}
+
+// prepareVendor fills in defaults and validates the details.
+func prepareVendor(details VendorDetails) (VendorDetails, error) {
+	details.Name = strings.TrimSpace(details.Name)
+	details.ContactName = strings.TrimSpace(details.ContactName)
+	details.Email = strings.TrimSpace(details.Email)
+	details.Phone = strings.TrimSpace(details.Phone)
+	if details.Trade == "" {
+		details.Trade = "general"
+	}
+
+	if details.Name == "" {
+		return VendorDetails{}, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if !isValidTrade(details.Trade) {
+		return VendorDetails{}, fmt.Errorf("%w: trade must be 'plumbing', 'electrical', 'hvac', 'appliance', 'carpentry', 'roofing', 'painting', 'cleaning', 'landscaping', 'pest_control', 'locksmith' or 'general'", ErrInvalidInput)
+	}
+	if details.Email != "" {
+		if _, err := mail.ParseAddress(details.Email); err != nil {
+			return VendorDetails{}, fmt.Errorf("%w: email is not valid", ErrInvalidInput)
+		}
+	}
+	if details.HourlyRate != nil && *details.HourlyRate < 0 {
+		return VendorDetails{}, fmt.Errorf("%w: hourly rate cannot be negative", ErrInvalidInput)
+	}
+	if details.InsuranceExpiresOn != nil {
+		expiresOn := dateOnly(*details.InsuranceExpiresOn)
+		details.InsuranceExpiresOn = &expiresOn
+	}
+	return details, nil
+}
+
+func applyVendorDetails(v *model.Vendor, details VendorDetails) {
+	v.Name = details.Name
+	v.Trade = details.Trade
+	v.ContactName = details.ContactName
+	v.Email = details.Email
+	v.Phone = details.Phone
+	v.InsuranceExpiresOn = details.InsuranceExpiresOn
+	v.HourlyRate = details.HourlyRate
+}
+
+func isValidTrade(trade string) bool {
+	switch trade {
+	case "plumbing", "electrical", "hvac", "appliance", "carpentry", "roofing", "painting", "cleaning",
+		"landscaping", "pest_control", "locksmith", "general":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+const applicationColumns = `id, organization_id, property_id, first_name, last_name, email, phone, employer, job_title, monthly_income,
+	desired_start_date, desired_end_date, status, decision_reason, decided_at, tenant_id, lease_id, created_at, updated_at`
+
+type ApplicationStore struct {
+	db *pgxpool.Pool
+}
+
+func NewApplicationStore(db *pgxpool.Pool) *ApplicationStore {
+	return &ApplicationStore{db: db}
+}
+
+// GetAll returns every application, optionally restricted to one status.
+func (s *ApplicationStore) GetAll(ctx context.Context, status string) ([]model.Application, error) {
+	conds := newConditions()
+	if status != "" {
+		conds.add("status = %s", status)
+	}
+	scopeOrganization(ctx, conds, "organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+applicationColumns+`
+		FROM applications
+		`+conds.where()+`
+		ORDER BY created_at DESC
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applications []model.Application
+	for rows.Next() {
+		a, err := scanApplication(rows)
+		if err != nil {
+			return nil, err
+		}
+		applications = append(applications, a)
+	}
+
+	return applications, rows.Err()
+}
+
+func (s *ApplicationStore) GetByID(ctx context.Context, id string) (model.Application, error) {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	a, err := scanApplication(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+applicationColumns+`
+		FROM applications
+		`+conds.where(), conds.args...))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Application{}, ErrNotFound
+	}
+	return a, err
+}
+
+func (s *ApplicationStore) Create(ctx context.Context, a model.Application) (model.Application, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO applications (`+applicationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	`, a.ID, a.OrganizationID, a.PropertyID, a.FirstName, a.LastName, a.Email, a.Phone, a.Employer, a.JobTitle, a.MonthlyIncome,
+		a.DesiredStartDate, a.DesiredEndDate, a.Status, a.DecisionReason, a.DecidedAt, a.TenantID, a.LeaseID, a.CreatedAt, a.UpdatedAt)
+
+	return a, err
+}
+
+func (s *ApplicationStore) Update(ctx context.Context, a model.Application) (model.Application, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE applications
+		SET first_name = $2, last_name = $3, email = $4, phone = $5, employer = $6, job_title = $7, monthly_income = $8,
+			desired_start_date = $9, desired_end_date = $10, status = $11, decision_reason = $12, decided_at = $13,
+			tenant_id = $14, lease_id = $15, updated_at = $16
+		WHERE id = $1
+	`, a.ID, a.FirstName, a.LastName, a.Email, a.Phone, a.Employer, a.JobTitle, a.MonthlyIncome,
+		a.DesiredStartDate, a.DesiredEndDate, a.Status, a.DecisionReason, a.DecidedAt, a.TenantID, a.LeaseID, a.UpdatedAt)
+
+	if err != nil {
+		return model.Application{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.Application{}, ErrNotFound
+	}
+	return a, nil
+}
+
+func (s *ApplicationStore) Delete(ctx context.Context, id string) error {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM applications `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanApplication(row pgx.Row) (model.Application, error) {
+	var a model.Application
+	err := row.Scan(&a.ID, &a.OrganizationID, &a.PropertyID, &a.FirstName, &a.LastName, &a.Email, &a.Phone, &a.Employer, &a.JobTitle, &a.MonthlyIncome,
+		&a.DesiredStartDate, &a.DesiredEndDate, &a.Status, &a.DecisionReason, &a.DecidedAt, &a.TenantID, &a.LeaseID, &a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+const buildingColumns = `id, organization_id, name, street, unit, city, region, postal_code, country, owner_id, amenities, created_at, updated_at`
+
+type BuildingStore struct {
+	db *pgxpool.Pool
+}
+
+func NewBuildingStore(db *pgxpool.Pool) *BuildingStore {
+	return &BuildingStore{db: db}
+}
+
+func (s *BuildingStore) GetAll(ctx context.Context) ([]model.Building, error) {
+	conds := newConditions()
+	scopeOrganization(ctx, conds, "organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+buildingColumns+`
+		FROM buildings
+		`+conds.where()+`
+		ORDER BY name
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buildings []model.Building
+	for rows.Next() {
+		b, err := scanBuilding(rows)
+		if err != nil {
+			return nil, err
+		}
+		buildings = append(buildings, b)
+	}
+
+	return buildings, rows.Err()
+}
+
+func (s *BuildingStore) GetByID(ctx context.Context, id string) (model.Building, error) {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	b, err := scanBuilding(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+buildingColumns+`
+		FROM buildings
+		`+conds.where(), conds.args...))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Building{}, ErrNotFound
+	}
+	return b, err
+}
+
+func (s *BuildingStore) Create(ctx context.Context, b model.Building) (model.Building, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO buildings (`+buildingColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, b.ID, b.OrganizationID, b.Name, b.Address.Street, b.Address.Unit, b.Address.City, b.Address.Region, b.Address.PostalCode, b.Address.Country, b.OwnerID, b.Amenities, b.CreatedAt, b.UpdatedAt)
+
+	return b, err
+}
+
+func (s *BuildingStore) Update(ctx context.Context, b model.Building) (model.Building, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE buildings
+		SET name = $2, street = $3, unit = $4, city = $5, region = $6, postal_code = $7, country = $8,
+			owner_id = $9, amenities = $10, updated_at = $11
+		WHERE id = $1
+	`, b.ID, b.Name, b.Address.Street, b.Address.Unit, b.Address.City, b.Address.Region, b.Address.PostalCode, b.Address.Country, b.OwnerID, b.Amenities, b.UpdatedAt)
+
+	if err != nil {
+		return model.Building{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.Building{}, ErrNotFound
+	}
+	return b, nil
+}
+
+func (s *BuildingStore) Delete(ctx context.Context, id string) error {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM buildings `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanBuilding(row pgx.Row) (model.Building, error) {
+	var b model.Building
+	err := row.Scan(&b.ID, &b.OrganizationID, &b.Name, &b.Address.Street, &b.Address.Unit, &b.Address.City, &b.Address.Region,
+		&b.Address.PostalCode, &b.Address.Country, &b.OwnerID, &b.Amenities, &b.CreatedAt, &b.UpdatedAt)
+	return b, err
+}
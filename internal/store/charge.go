@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type ChargeStore struct {
+	db *pgxpool.Pool
+}
+
+func NewChargeStore(db *pgxpool.Pool) *ChargeStore {
+	return &ChargeStore{db: db}
+}
+
+func (s *ChargeStore) GetByLeaseID(ctx context.Context, leaseID string) ([]model.LeaseCharge, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT id, lease_id, invoice_id, type, description, amount, charge_date, created_at
+		FROM lease_charges
+		WHERE lease_id = $1
+		ORDER BY charge_date, created_at
+	`, leaseID)
+	if err != nil {
+		return nil, err
+	}
+	return scanCharges(rows)
+}
+
+func (s *ChargeStore) GetByInvoiceID(ctx context.Context, invoiceID string) ([]model.LeaseCharge, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT id, lease_id, invoice_id, type, description, amount, charge_date, created_at
+		FROM lease_charges
+		WHERE invoice_id = $1
+		ORDER BY charge_date, created_at
+	`, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	return scanCharges(rows)
+}
+
+// Create inserts a charge unless an identical one (same invoice, type and
+// date) already exists. It reports whether a new row was written.
+func (s *ChargeStore) Create(ctx context.Context, c model.LeaseCharge) (bool, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO lease_charges (id, lease_id, invoice_id, type, description, amount, charge_date, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (invoice_id, type, charge_date) DO NOTHING
+	`, c.ID, c.LeaseID, c.InvoiceID, c.Type, c.Description, c.Amount, c.ChargeDate, c.CreatedAt)
+
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+func scanCharges(rows pgx.Rows) ([]model.LeaseCharge, error) {
+	defer rows.Close()
+
+	var charges []model.LeaseCharge
+	for rows.Next() {
+		var c model.LeaseCharge
+		err := rows.Scan(&c.ID, &c.LeaseID, &c.InvoiceID, &c.Type, &c.Description, &c.Amount, &c.ChargeDate, &c.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		charges = append(charges, c)
+	}
+
+	return charges, rows.Err()
+}
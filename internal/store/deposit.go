@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type DepositStore struct {
+	db *pgxpool.Pool
+}
+
+func NewDepositStore(db *pgxpool.Pool) *DepositStore {
+	return &DepositStore{db: db}
+}
+
+func (s *DepositStore) GetAll(ctx context.Context, status string) ([]model.DepositSettlement, error) {
+	conds := newConditions()
+	if status != "" {
+		conds.add("status = %s", status)
+	}
+	scopeLeaseOrganization(ctx, conds, "deposit_settlements.lease_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT id, lease_id, deposit_amount, status, deadline, settled_at, created_at, updated_at
+		FROM deposit_settlements
+		`+conds.where()+`
+		ORDER BY deadline
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var settlements []model.DepositSettlement
+	for rows.Next() {
+		var d model.DepositSettlement
+		err := rows.Scan(&d.ID, &d.LeaseID, &d.DepositAmount, &d.Status, &d.Deadline, &d.SettledAt, &d.CreatedAt, &d.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		settlements = append(settlements, d)
+	}
+
+	return settlements, rows.Err()
+}
+
+func (s *DepositStore) GetByLeaseID(ctx context.Context, leaseID string) (model.DepositSettlement, error) {
+	var d model.DepositSettlement
+	err := conn(ctx, s.db).QueryRow(ctx, `
+		SELECT id, lease_id, deposit_amount, status, deadline, settled_at, created_at, updated_at
+		FROM deposit_settlements
+		WHERE lease_id = $1
+	`, leaseID).Scan(&d.ID, &d.LeaseID, &d.DepositAmount, &d.Status, &d.Deadline, &d.SettledAt, &d.CreatedAt, &d.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.DepositSettlement{}, ErrNotFound
+	}
+	return d, err
+}
+
+// Create opens a settlement unless the lease already has one. It reports
+// whether a new row was written.
+func (s *DepositStore) Create(ctx context.Context, d model.DepositSettlement) (bool, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO deposit_settlements (id, lease_id, deposit_amount, status, deadline, settled_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (lease_id) DO NOTHING
+	`, d.ID, d.LeaseID, d.DepositAmount, d.Status, d.Deadline, d.SettledAt, d.CreatedAt, d.UpdatedAt)
+
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+func (s *DepositStore) Update(ctx context.Context, d model.DepositSettlement) (model.DepositSettlement, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE deposit_settlements
+		SET status = $2, deadline = $3, settled_at = $4, updated_at = $5
+		WHERE id = $1
+	`, d.ID, d.Status, d.Deadline, d.SettledAt, d.UpdatedAt)
+
+	if err != nil {
+		return model.DepositSettlement{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.DepositSettlement{}, ErrNotFound
+	}
+	return d, nil
+}
+
+func (s *DepositStore) GetDeductions(ctx context.Context, settlementID string) ([]model.DepositDeduction, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT id, settlement_id, category, description, amount, created_at
+		FROM deposit_deductions
+		WHERE settlement_id = $1
+		ORDER BY created_at
+	`, settlementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deductions []model.DepositDeduction
+	for rows.Next() {
+		var d model.DepositDeduction
+		err := rows.Scan(&d.ID, &d.SettlementID, &d.Category, &d.Description, &d.Amount, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		deductions = append(deductions, d)
+	}
+
+	return deductions, rows.Err()
+}
+
+func (s *DepositStore) CreateDeduction(ctx context.Context, d model.DepositDeduction) (model.DepositDeduction, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO deposit_deductions (id, settlement_id, category, description, amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, d.ID, d.SettlementID, d.Category, d.Description, d.Amount, d.CreatedAt)
+
+	return d, err
+}
+
+func (s *DepositStore) DeleteDeduction(ctx context.Context, settlementID, id string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM deposit_deductions WHERE id = $1 AND settlement_id = $2
+	`, id, settlementID)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
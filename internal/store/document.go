@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type DocumentStore struct {
+	db *pgxpool.Pool
+}
+
+func NewDocumentStore(db *pgxpool.Pool) *DocumentStore {
+	return &DocumentStore{db: db}
+}
+
+// GetByLeaseIDs returns the documents of any of the given leases, newest
+// first.
+func (s *DocumentStore) GetByLeaseIDs(ctx context.Context, leaseIDs []string) ([]model.Document, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT id, lease_id, title, category, url, created_at
+		FROM lease_documents
+		WHERE lease_id = ANY($1)
+		ORDER BY created_at DESC
+	`, leaseIDs)
+	if err != nil {
+		return nil, err
+	}
+	return scanDocuments(rows)
+}
+
+func (s *DocumentStore) Create(ctx context.Context, d model.Document) (model.Document, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO lease_documents (id, lease_id, title, category, url, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, d.ID, d.LeaseID, d.Title, d.Category, d.URL, d.CreatedAt)
+
+	return d, err
+}
+
+func (s *DocumentStore) Delete(ctx context.Context, leaseID, id string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM lease_documents WHERE id = $1 AND lease_id = $2
+	`, id, leaseID)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanDocuments(rows pgx.Rows) ([]model.Document, error) {
+	defer rows.Close()
+
+	documents := []model.Document{}
+	for rows.Next() {
+		var d model.Document
+		if err := rows.Scan(&d.ID, &d.LeaseID, &d.Title, &d.Category, &d.URL, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		documents = append(documents, d)
+	}
+
+	return documents, rows.Err()
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+const expenseColumns = `id, property_id, maintenance_request_id, incurred_on, category, description, amount, created_at`
+
+type ExpenseStore struct {
+	db *pgxpool.Pool
+}
+
+func NewExpenseStore(db *pgxpool.Pool) *ExpenseStore {
+	return &ExpenseStore{db: db}
+}
+
+func (s *ExpenseStore) GetByPropertyID(ctx context.Context, propertyID string) ([]model.Expense, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+expenseColumns+`
+		FROM property_expenses
+		WHERE property_id = $1
+		ORDER BY incurred_on DESC, created_at DESC
+	`, propertyID)
+	if err != nil {
+		return nil, err
+	}
+	return scanExpenses(rows)
+}
+
+// GetByMaintenanceRequestID returns the costs recorded against a
+// maintenance request.
+func (s *ExpenseStore) GetByMaintenanceRequestID(ctx context.Context, requestID string) ([]model.Expense, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+expenseColumns+`
+		FROM property_expenses
+		WHERE maintenance_request_id = $1
+		ORDER BY incurred_on DESC, created_at DESC
+	`, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return scanExpenses(rows)
+}
+
+func (s *ExpenseStore) Create(ctx context.Context, e model.Expense) (model.Expense, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO property_expenses (`+expenseColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, e.ID, e.PropertyID, e.MaintenanceRequestID, e.IncurredOn, e.Category, e.Description, e.Amount, e.CreatedAt)
+
+	return e, err
+}
+
+func (s *ExpenseStore) Delete(ctx context.Context, propertyID, id string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM property_expenses WHERE id = $1 AND property_id = $2
+	`, id, propertyID)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteMaintenanceCost removes a cost recorded against a maintenance
+// request.
+func (s *ExpenseStore) DeleteMaintenanceCost(ctx context.Context, requestID, id string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM property_expenses WHERE id = $1 AND maintenance_request_id = $2
+	`, id, requestID)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanExpenses(rows pgx.Rows) ([]model.Expense, error) {
+	defer rows.Close()
+
+	var expenses []model.Expense
+	for rows.Next() {
+		var e model.Expense
+		err := rows.Scan(&e.ID, &e.PropertyID, &e.MaintenanceRequestID, &e.IncurredOn, &e.Category, &e.Description, &e.Amount, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, e)
+	}
+
+	return expenses, rows.Err()
+}
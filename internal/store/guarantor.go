@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+const guarantorColumns = `id, organization_id, first_name, last_name, email, phone, address, annual_income, liability_cap, created_at, updated_at`
+
+type GuarantorStore struct {
+	db *pgxpool.Pool
+}
+
+func NewGuarantorStore(db *pgxpool.Pool) *GuarantorStore {
+	return &GuarantorStore{db: db}
+}
+
+func (s *GuarantorStore) GetAll(ctx context.Context) ([]model.Guarantor, error) {
+	conds := newConditions()
+	scopeOrganization(ctx, conds, "organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+guarantorColumns+`
+		FROM guarantors
+		`+conds.where()+`
+		ORDER BY created_at DESC
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanGuarantors(rows)
+}
+
+func (s *GuarantorStore) GetByLeaseID(ctx context.Context, leaseID string) ([]model.Guarantor, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT g.id, g.organization_id, g.first_name, g.last_name, g.email, g.phone, g.address, g.annual_income, g.liability_cap, g.created_at, g.updated_at
+		FROM guarantors g
+		JOIN lease_guarantors lg ON lg.guarantor_id = g.id
+		WHERE lg.lease_id = $1
+		ORDER BY lg.created_at
+	`, leaseID)
+	if err != nil {
+		return nil, err
+	}
+	return scanGuarantors(rows)
+}
+
+func (s *GuarantorStore) GetByID(ctx context.Context, id string) (model.Guarantor, error) {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	var g model.Guarantor
+	err := conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+guarantorColumns+`
+		FROM guarantors
+		`+conds.where(), conds.args...).Scan(&g.ID, &g.OrganizationID, &g.FirstName, &g.LastName, &g.Email, &g.Phone, &g.Address, &g.AnnualIncome, &g.LiabilityCap, &g.CreatedAt, &g.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Guarantor{}, ErrNotFound
+	}
+	return g, err
+}
+
+func (s *GuarantorStore) Create(ctx context.Context, g model.Guarantor) (model.Guarantor, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO guarantors (`+guarantorColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, g.ID, g.OrganizationID, g.FirstName, g.LastName, g.Email, g.Phone, g.Address, g.AnnualIncome, g.LiabilityCap, g.CreatedAt, g.UpdatedAt)
+
+	return g, err
+}
+
+func (s *GuarantorStore) Update(ctx context.Context, g model.Guarantor) (model.Guarantor, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE guarantors
+		SET first_name = $2, last_name = $3, email = $4, phone = $5, address = $6, annual_income = $7, liability_cap = $8, updated_at = $9
+		WHERE id = $1
+	`, g.ID, g.FirstName, g.LastName, g.Email, g.Phone, g.Address, g.AnnualIncome, g.LiabilityCap, g.UpdatedAt)
+
+	if err != nil {
+		return model.Guarantor{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.Guarantor{}, ErrNotFound
+	}
+	return g, nil
+}
+
+func (s *GuarantorStore) Delete(ctx context.Context, id string) error {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM guarantors `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *GuarantorStore) Link(ctx context.Context, leaseID, guarantorID string) error {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO lease_guarantors (lease_id, guarantor_id)
+		VALUES ($1, $2)
+	`, leaseID, guarantorID)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicate
+	}
+	return err
+}
+
+func (s *GuarantorStore) Unlink(ctx context.Context, leaseID, guarantorID string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM lease_guarantors WHERE lease_id = $1 AND guarantor_id = $2
+	`, leaseID, guarantorID)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanGuarantors(rows pgx.Rows) ([]model.Guarantor, error) {
+	defer rows.Close()
+
+	var guarantors []model.Guarantor
+	for rows.Next() {
+		var g model.Guarantor
+		err := rows.Scan(&g.ID, &g.OrganizationID, &g.FirstName, &g.LastName, &g.Email, &g.Phone, &g.Address, &g.AnnualIncome, &g.LiabilityCap, &g.CreatedAt, &g.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		guarantors = append(guarantors, g)
+	}
+
+	return guarantors, rows.Err()
+}
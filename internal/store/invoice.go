@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+// invoiceSource allocates each lease's payments to its invoices oldest first,
+// so amount_paid and status are always derived from the payments table and
+// never drift out of sync with it.
+const invoiceSource = `(
+	SELECT a.*,
+		a.amount - a.amount_paid AS amount_due,
+		CASE WHEN a.amount_paid >= a.amount THEN 'paid' WHEN a.amount_paid > 0 THEN 'partial' ELSE 'unpaid' END AS status
+	FROM (
+		SELECT i.*,
+			LEAST(i.amount, GREATEST(0, COALESCE(p.total, 0) - (SUM(i.amount) OVER (PARTITION BY i.lease_id ORDER BY i.period_start) - i.amount))) AS amount_paid
+		FROM invoices i
+		LEFT JOIN (
+			SELECT lease_id, SUM(amount) AS total FROM payments GROUP BY lease_id
+		) p ON p.lease_id = i.lease_id
+	) a
+) inv`
+
+const invoiceColumns = `id, lease_id, period_start, period_end, due_date, amount, amount_paid, amount_due, status, created_at`
+
+type InvoiceStore struct {
+	db *pgxpool.Pool
+}
+
+func NewInvoiceStore(db *pgxpool.Pool) *InvoiceStore {
+	return &InvoiceStore{db: db}
+}
+
+// GetAll returns every invoice, optionally restricted to one status
+// ("unpaid", "partial" or "paid"). An empty status matches all invoices.
+func (s *InvoiceStore) GetAll(ctx context.Context, status string) ([]model.Invoice, error) {
+	conds := newConditions()
+	if status != "" {
+		conds.add("status = %s", status)
+	}
+	scopeLeaseOrganization(ctx, conds, "inv.lease_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+invoiceColumns+`
+		FROM `+invoiceSource+`
+		`+conds.where()+`
+		ORDER BY due_date, lease_id
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanInvoices(rows)
+}
+
+func (s *InvoiceStore) GetByLeaseID(ctx context.Context, leaseID string) ([]model.Invoice, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+invoiceColumns+`
+		FROM `+invoiceSource+`
+		WHERE lease_id = $1
+		ORDER BY period_start
+	`, leaseID)
+	if err != nil {
+		return nil, err
+	}
+	return scanInvoices(rows)
+}
+
+// Create inserts an invoice unless one already exists for the same lease and
+// period. It reports whether a new row was written.
+func (s *InvoiceStore) Create(ctx context.Context, i model.Invoice) (bool, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO invoices (id, lease_id, period_start, period_end, due_date, amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (lease_id, period_start) DO NOTHING
+	`, i.ID, i.LeaseID, i.PeriodStart, i.PeriodEnd, i.DueDate, i.Amount, i.CreatedAt)
+
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// DeleteAfter removes a lease's invoices for periods starting after the
+// given date.
+func (s *InvoiceStore) DeleteAfter(ctx context.Context, leaseID string, after time.Time) error {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM invoices WHERE lease_id = $1 AND period_start > $2
+	`, leaseID, after)
+
+	return err
+}
+
+// Reprice changes the end and amount of the lease's invoice for the period
+// starting on periodStart, if there is one.
+func (s *InvoiceStore) Reprice(ctx context.Context, leaseID string, periodStart, periodEnd time.Time, amount float64) error {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE invoices SET period_end = $3, amount = $4
+		WHERE lease_id = $1 AND period_start = $2
+	`, leaseID, periodStart, periodEnd, amount)
+
+	return err
+}
+
+func scanInvoices(rows pgx.Rows) ([]model.Invoice, error) {
+	defer rows.Close()
+
+	var invoices []model.Invoice
+	for rows.Next() {
+		var i model.Invoice
+		err := rows.Scan(&i.ID, &i.LeaseID, &i.PeriodStart, &i.PeriodEnd, &i.DueDate, &i.Amount, &i.AmountPaid, &i.AmountDue, &i.Status, &i.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, i)
+	}
+
+	return invoices, rows.Err()
+}
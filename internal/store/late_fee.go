@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type LateFeePolicyStore struct {
+	db *pgxpool.Pool
+}
+
+func NewLateFeePolicyStore(db *pgxpool.Pool) *LateFeePolicyStore {
+	return &LateFeePolicyStore{db: db}
+}
+
+func (s *LateFeePolicyStore) GetByPropertyID(ctx context.Context, propertyID string) (model.LateFeePolicy, error) {
+	return s.getBy(ctx, "property_id", propertyID)
+}
+
+func (s *LateFeePolicyStore) GetByLeaseID(ctx context.Context, leaseID string) (model.LateFeePolicy, error) {
+	return s.getBy(ctx, "lease_id", leaseID)
+}
+
+func (s *LateFeePolicyStore) getBy(ctx context.Context, column, id string) (model.LateFeePolicy, error) {
+	conds := s.conditions(ctx, column, id)
+
+	var p model.LateFeePolicy
+	err := conn(ctx, s.db).QueryRow(ctx, `
+		SELECT id, property_id, lease_id, grace_days, flat_fee, percent, daily_fee, max_fee, created_at, updated_at
+		FROM late_fee_policies
+		`+conds.where(), conds.args...).Scan(&p.ID, &p.PropertyID, &p.LeaseID, &p.GraceDays, &p.FlatFee, &p.Percent, &p.DailyFee, &p.MaxFee, &p.CreatedAt, &p.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.LateFeePolicy{}, ErrNotFound
+	}
+	return p, err
+}
+
+// Save creates the policy or replaces the existing one for the same property
+// or lease, returning the stored row.
+func (s *LateFeePolicyStore) Save(ctx context.Context, p model.LateFeePolicy) (model.LateFeePolicy, error) {
+	conflict := "property_id"
+	if p.LeaseID != nil {
+		conflict = "lease_id"
+	}
+
+	var saved model.LateFeePolicy
+	err := conn(ctx, s.db).QueryRow(ctx, `
+		INSERT INTO late_fee_policies (id, property_id, lease_id, grace_days, flat_fee, percent, daily_fee, max_fee, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (`+conflict+`) DO UPDATE
+		SET grace_days = $4, flat_fee = $5, percent = $6, daily_fee = $7, max_fee = $8, updated_at = $10
+		RETURNING id, property_id, lease_id, grace_days, flat_fee, percent, daily_fee, max_fee, created_at, updated_at
+	`, p.ID, p.PropertyID, p.LeaseID, p.GraceDays, p.FlatFee, p.Percent, p.DailyFee, p.MaxFee, p.CreatedAt, p.UpdatedAt).
+		Scan(&saved.ID, &saved.PropertyID, &saved.LeaseID, &saved.GraceDays, &saved.FlatFee, &saved.Percent, &saved.DailyFee, &saved.MaxFee, &saved.CreatedAt, &saved.UpdatedAt)
+
+	return saved, err
+}
+
+func (s *LateFeePolicyStore) DeleteByPropertyID(ctx context.Context, propertyID string) error {
+	return s.deleteBy(ctx, "property_id", propertyID)
+}
+
+func (s *LateFeePolicyStore) DeleteByLeaseID(ctx context.Context, leaseID string) error {
+	return s.deleteBy(ctx, "lease_id", leaseID)
+}
+
+func (s *LateFeePolicyStore) deleteBy(ctx context.Context, column, id string) error {
+	conds := s.conditions(ctx, column, id)
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM late_fee_policies `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// conditions matches the policy of one property or lease, limited to the
+// user's organization through that property or lease.
+func (s *LateFeePolicyStore) conditions(ctx context.Context, column, id string) *conditions {
+	conds := newConditions()
+	conds.add(column+" = %s", id)
+	if column == "lease_id" {
+		scopeLeaseOrganization(ctx, conds, "lease_id")
+	} else {
+		scopePropertyOrganization(ctx, conds, "property_id")
+	}
+	return conds
+}
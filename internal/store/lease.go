@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -10,6 +11,9 @@ import (
 	"github.com/Lacsw/rntly/internal/model"
 )
 
+const leaseColumns = `id, organization_id, property_id, tenant_id, start_date, end_date, rent_amount, deposit, status, previous_lease_id,
+	notice_date, move_out_date, termination_reason, termination_fee, original_end_date, created_at, updated_at`
+
 type LeaseStore struct {
 	db *pgxpool.Pool
 }
@@ -18,109 +22,158 @@ func NewLeaseStore(db *pgxpool.Pool) *LeaseStore {
 	return &LeaseStore{db: db}
 }
 
+// GetAll returns every lease regardless of who is asking. It is meant for
+// background jobs; requests list leases through List.
 func (s *LeaseStore) GetAll(ctx context.Context) ([]model.Lease, error) {
-	rows, err := s.db.Query(ctx, `
-		SELECT id, property_id, tenant_id, start_date, end_date, rent_amount, deposit, status, created_at, updated_at
+	return s.query(ctx, `
+		SELECT `+leaseColumns+`
 		FROM leases
 		ORDER BY created_at DESC
 	`)
+}
+
+// leaseSortColumns are the fields a lease listing can be sorted by.
+var leaseSortColumns = map[string]sortColumn[model.Lease]{
+	"created_at":  {"created_at", "timestamp", func(l model.Lease) string { return formatTime(l.CreatedAt) }},
+	"updated_at":  {"updated_at", "timestamp", func(l model.Lease) string { return formatTime(l.UpdatedAt) }},
+	"start_date":  {"start_date", "date", func(l model.Lease) string { return l.StartDate.Format("2006-01-02") }},
+	"end_date":    {"end_date", "date", func(l model.Lease) string { return l.EndDate.Format("2006-01-02") }},
+	"rent_amount": {"rent_amount", "numeric", func(l model.Lease) string { return formatFloat(l.RentAmount) }},
+}
+
+// List returns one page of the leases matching the filter.
+func (s *LeaseStore) List(ctx context.Context, filter model.LeaseFilter, page model.PageRequest) (model.Page[model.Lease], error) {
+	conds := newConditions()
+	scopeLeases(ctx, conds)
+	if filter.Status != "" {
+		conds.add("status = %s", filter.Status)
+	}
+	if filter.PropertyID != "" {
+		conds.add("property_id = %s", filter.PropertyID)
+	}
+	if filter.TenantID != "" {
+		conds.add("(tenant_id = %s OR EXISTS (SELECT 1 FROM lease_parties lp WHERE lp.lease_id = leases.id AND lp.tenant_id = %s))",
+			filter.TenantID, filter.TenantID)
+	}
+	if filter.MinRent != nil {
+		conds.add("rent_amount >= %s", *filter.MinRent)
+	}
+	if filter.MaxRent != nil {
+		conds.add("rent_amount <= %s", *filter.MaxRent)
+	}
+	if filter.StartFrom != nil {
+		conds.add("start_date >= %s", *filter.StartFrom)
+	}
+	if filter.StartTo != nil {
+		conds.add("start_date <= %s", *filter.StartTo)
+	}
+	if filter.EndFrom != nil {
+		conds.add("end_date >= %s", *filter.EndFrom)
+	}
+	if filter.EndTo != nil {
+		conds.add("end_date <= %s", *filter.EndTo)
+	}
+
+	var total int
+	err := conn(ctx, s.db).QueryRow(ctx, `SELECT COUNT(*) FROM leases `+conds.where(), conds.args...).Scan(&total)
 	if err != nil {
-		return nil, err
+		return model.Page[model.Lease]{}, err
 	}
-	defer rows.Close()
 
-	var leases []model.Lease
-	for rows.Next() {
-		var l model.Lease
-		err := rows.Scan(&l.ID, &l.PropertyID, &l.TenantID, &l.StartDate, &l.EndDate, &l.RentAmount, &l.Deposit, &l.Status, &l.CreatedAt, &l.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		leases = append(leases, l)
+	col, order, err := keyset(conds, leaseSortColumns, "id", page)
+	if err != nil {
+		return model.Page[model.Lease]{}, err
 	}
 
-	return leases, nil
+	leases, err := s.query(ctx, `
+		SELECT `+leaseColumns+`
+		FROM leases
+		`+conds.where()+order, conds.args...)
+	if err != nil {
+		return model.Page[model.Lease]{}, err
+	}
+
+	return newPage(leases, total, col, func(l model.Lease) string { return l.ID }, page), nil
 }
 
 func (s *LeaseStore) GetByID(ctx context.Context, id string) (model.Lease, error) {
-	var l model.Lease
-	err := s.db.QueryRow(ctx, `
-		SELECT id, property_id, tenant_id, start_date, end_date, rent_amount, deposit, status, created_at, updated_at
-		FROM leases
-		WHERE id = $1
-	`, id).Scan(&l.ID, &l.PropertyID, &l.TenantID, &l.StartDate, &l.EndDate, &l.RentAmount, &l.Deposit, &l.Status, &l.CreatedAt, &l.UpdatedAt)
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeLeases(ctx, conds)
 
-	if errors.Is(err, pgx.ErrNoRows) {
-		return model.Lease{}, ErrNotFound
-	}
-	return l, err
+	return s.queryOne(ctx, `
+		SELECT `+leaseColumns+`
+		FROM leases
+		`+conds.where(), conds.args...)
 }
 
 func (s *LeaseStore) GetByPropertyID(ctx context.Context, propertyID string) ([]model.Lease, error) {
-	rows, err := s.db.Query(ctx, `
-		SELECT id, property_id, tenant_id, start_date, end_date, rent_amount, deposit, status, created_at, updated_at
+	conds := newConditions()
+	conds.add("property_id = %s", propertyID)
+	scopeLeases(ctx, conds)
+
+	return s.query(ctx, `
+		SELECT `+leaseColumns+`
 		FROM leases
-		WHERE property_id = $1
+		`+conds.where()+`
 		ORDER BY start_date DESC
-	`, propertyID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var leases []model.Lease
-	for rows.Next() {
-		var l model.Lease
-		err := rows.Scan(&l.ID, &l.PropertyID, &l.TenantID, &l.StartDate, &l.EndDate, &l.RentAmount, &l.Deposit, &l.Status, &l.CreatedAt, &l.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		leases = append(leases, l)
-	}
-
-	return leases, nil
+	`, conds.args...)
 }
 
+// GetByTenantID returns the leases the tenant is a party to in any role.
 func (s *LeaseStore) GetByTenantID(ctx context.Context, tenantID string) ([]model.Lease, error) {
-	rows, err := s.db.Query(ctx, `
-		SELECT id, property_id, tenant_id, start_date, end_date, rent_amount, deposit, status, created_at, updated_at
+	conds := newConditions()
+	conds.add("(tenant_id = %s OR EXISTS (SELECT 1 FROM lease_parties lp WHERE lp.lease_id = leases.id AND lp.tenant_id = %s))",
+		tenantID, tenantID)
+	scopeLeases(ctx, conds)
+
+	return s.query(ctx, `
+		SELECT `+leaseColumns+`
 		FROM leases
-		WHERE tenant_id = $1
+		`+conds.where()+`
 		ORDER BY start_date DESC
-	`, tenantID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	`, conds.args...)
+}
 
-	var leases []model.Lease
-	for rows.Next() {
-		var l model.Lease
-		err := rows.Scan(&l.ID, &l.PropertyID, &l.TenantID, &l.StartDate, &l.EndDate, &l.RentAmount, &l.Deposit, &l.Status, &l.CreatedAt, &l.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		leases = append(leases, l)
-	}
+// GetByPreviousLeaseID returns the lease that renewed the given one.
+func (s *LeaseStore) GetByPreviousLeaseID(ctx context.Context, previousLeaseID string) (model.Lease, error) {
+	conds := newConditions()
+	conds.add("previous_lease_id = %s", previousLeaseID)
+	scopeLeases(ctx, conds)
 
-	return leases, nil
+	return s.queryOne(ctx, `
+		SELECT `+leaseColumns+`
+		FROM leases
+		`+conds.where(), conds.args...)
 }
 
+// Create inserts the lease together with its primary lease party in a
+// single statement.
 func (s *LeaseStore) Create(ctx context.Context, l model.Lease) (model.Lease, error) {
-	_, err := s.db.Exec(ctx, `
-		INSERT INTO leases (id, property_id, tenant_id, start_date, end_date, rent_amount, deposit, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	`, l.ID, l.PropertyID, l.TenantID, l.StartDate, l.EndDate, l.RentAmount, l.Deposit, l.Status, l.CreatedAt, l.UpdatedAt)
+	t := terminationColumns(l.Termination)
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		WITH lease AS (
+			INSERT INTO leases (`+leaseColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+			RETURNING id, tenant_id, created_at
+		)
+		INSERT INTO lease_parties (lease_id, tenant_id, role, created_at)
+		SELECT id, tenant_id, 'primary', created_at FROM lease
+	`, l.ID, l.OrganizationID, l.PropertyID, l.TenantID, l.StartDate, l.EndDate, l.RentAmount, l.Deposit, l.Status, l.PreviousLeaseID,
+		t.noticeDate, t.moveOutDate, t.reason, t.fee, t.originalEndDate, l.CreatedAt, l.UpdatedAt)
 
 	return l, err
 }
 
 func (s *LeaseStore) Update(ctx context.Context, l model.Lease) (model.Lease, error) {
-	result, err := s.db.Exec(ctx, `
+	t := terminationColumns(l.Termination)
+	result, err := conn(ctx, s.db).Exec(ctx, `
 		UPDATE leases
-		SET property_id = $2, tenant_id = $3, start_date = $4, end_date = $5, rent_amount = $6, deposit = $7, status = $8, updated_at = $9
+		SET property_id = $2, tenant_id = $3, start_date = $4, end_date = $5, rent_amount = $6, deposit = $7, status = $8,
+			notice_date = $9, move_out_date = $10, termination_reason = $11, termination_fee = $12, original_end_date = $13, updated_at = $14
 		WHERE id = $1
-	`, l.ID, l.PropertyID, l.TenantID, l.StartDate, l.EndDate, l.RentAmount, l.Deposit, l.Status, l.UpdatedAt)
+	`, l.ID, l.PropertyID, l.TenantID, l.StartDate, l.EndDate, l.RentAmount, l.Deposit, l.Status,
+		t.noticeDate, t.moveOutDate, t.reason, t.fee, t.originalEndDate, l.UpdatedAt)
 
 	if err != nil {
 		return model.Lease{}, err
@@ -132,9 +185,11 @@ func (s *LeaseStore) Update(ctx context.Context, l model.Lease) (model.Lease, er
 }
 
 func (s *LeaseStore) Delete(ctx context.Context, id string) error {
-	result, err := s.db.Exec(ctx, `
-		DELETE FROM leases WHERE id = $1
-	`, id)
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM leases `+conds.where(), conds.args...)
 
 	if err != nil {
 		return err
@@ -144,3 +199,75 @@ func (s *LeaseStore) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func (s *LeaseStore) query(ctx context.Context, sql string, args ...any) ([]model.Lease, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leases []model.Lease
+	for rows.Next() {
+		l, err := scanLease(rows)
+		if err != nil {
+			return nil, err
+		}
+		leases = append(leases, l)
+	}
+
+	return leases, rows.Err()
+}
+
+func (s *LeaseStore) queryOne(ctx context.Context, sql string, args ...any) (model.Lease, error) {
+	l, err := scanLease(conn(ctx, s.db).QueryRow(ctx, sql, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Lease{}, ErrNotFound
+	}
+	return l, err
+}
+
+// scanLease reads a row selected with leaseColumns.
+func scanLease(row pgx.Row) (model.Lease, error) {
+	var l model.Lease
+	var t terminationRow
+	err := row.Scan(&l.ID, &l.OrganizationID, &l.PropertyID, &l.TenantID, &l.StartDate, &l.EndDate, &l.RentAmount, &l.Deposit, &l.Status, &l.PreviousLeaseID,
+		&t.noticeDate, &t.moveOutDate, &t.reason, &t.fee, &t.originalEndDate, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		return model.Lease{}, err
+	}
+
+	if t.noticeDate != nil {
+		l.Termination = &model.LeaseTermination{
+			NoticeDate:      *t.noticeDate,
+			MoveOutDate:     *t.moveOutDate,
+			Reason:          *t.reason,
+			Fee:             *t.fee,
+			OriginalEndDate: *t.originalEndDate,
+		}
+	}
+	return l, nil
+}
+
+// terminationRow holds the nullable termination columns of a lease; they
+// are either all set or all NULL.
+type terminationRow struct {
+	noticeDate      *time.Time
+	moveOutDate     *time.Time
+	reason          *string
+	fee             *float64
+	originalEndDate *time.Time
+}
+
+func terminationColumns(t *model.LeaseTermination) terminationRow {
+	if t == nil {
+		return terminationRow{}
+	}
+	return terminationRow{
+		noticeDate:      &t.NoticeDate,
+		moveOutDate:     &t.MoveOutDate,
+		reason:          &t.Reason,
+		fee:             &t.Fee,
+		originalEndDate: &t.OriginalEndDate,
+	}
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type LeasePartyStore struct {
+	db *pgxpool.Pool
+}
+
+func NewLeasePartyStore(db *pgxpool.Pool) *LeasePartyStore {
+	return &LeasePartyStore{db: db}
+}
+
+func (s *LeasePartyStore) GetByLeaseID(ctx context.Context, leaseID string) ([]model.LeaseParty, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT lp.lease_id, lp.tenant_id, lp.role, t.first_name, t.last_name, t.email, t.phone, lp.created_at
+		FROM lease_parties lp
+		JOIN tenants t ON t.id = lp.tenant_id
+		WHERE lp.lease_id = $1
+		ORDER BY lp.role = 'primary' DESC, lp.created_at
+	`, leaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var parties []model.LeaseParty
+	for rows.Next() {
+		var p model.LeaseParty
+		err := rows.Scan(&p.LeaseID, &p.TenantID, &p.Role, &p.FirstName, &p.LastName, &p.Email, &p.Phone, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		parties = append(parties, p)
+	}
+
+	return parties, rows.Err()
+}
+
+func (s *LeasePartyStore) GetByID(ctx context.Context, leaseID, tenantID string) (model.LeaseParty, error) {
+	var p model.LeaseParty
+	err := conn(ctx, s.db).QueryRow(ctx, `
+		SELECT lp.lease_id, lp.tenant_id, lp.role, t.first_name, t.last_name, t.email, t.phone, lp.created_at
+		FROM lease_parties lp
+		JOIN tenants t ON t.id = lp.tenant_id
+		WHERE lp.lease_id = $1 AND lp.tenant_id = $2
+	`, leaseID, tenantID).Scan(&p.LeaseID, &p.TenantID, &p.Role, &p.FirstName, &p.LastName, &p.Email, &p.Phone, &p.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.LeaseParty{}, ErrNotFound
+	}
+	return p, err
+}
+
+func (s *LeasePartyStore) Create(ctx context.Context, p model.LeaseParty) (model.LeaseParty, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO lease_parties (lease_id, tenant_id, role, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, p.LeaseID, p.TenantID, p.Role, p.CreatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return model.LeaseParty{}, ErrDuplicate
+	}
+	return p, err
+}
+
+func (s *LeasePartyStore) UpdateRole(ctx context.Context, leaseID, tenantID, role string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE lease_parties SET role = $3 WHERE lease_id = $1 AND tenant_id = $2
+	`, leaseID, tenantID, role)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *LeasePartyStore) Delete(ctx context.Context, leaseID, tenantID string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM lease_parties WHERE lease_id = $1 AND tenant_id = $2
+	`, leaseID, tenantID)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+const maintenanceColumns = `id, organization_id, property_id, lease_id, tenant_id, vendor_id, title, description, priority, category,
+	status, resolved_at, closed_at, created_at, updated_at`
+
+// selectMaintenance reads requests with the total of their recorded costs.
+const selectMaintenance = `
+	SELECT m.id, m.organization_id, m.property_id, m.lease_id, m.tenant_id, m.vendor_id, m.title, m.description, m.priority, m.category,
+		m.status, COALESCE((SELECT SUM(e.amount) FROM property_expenses e WHERE e.maintenance_request_id = m.id), 0),
+		m.resolved_at, m.closed_at, m.created_at, m.updated_at
+	FROM maintenance_requests m
+`
+
+type MaintenanceStore struct {
+	db *pgxpool.Pool
+}
+
+func NewMaintenanceStore(db *pgxpool.Pool) *MaintenanceStore {
+	return &MaintenanceStore{db: db}
+}
+
+// GetAll returns the requests matching the filter, newest first.
+func (s *MaintenanceStore) GetAll(ctx context.Context, filter model.MaintenanceFilter) ([]model.MaintenanceRequest, error) {
+	conds := newConditions()
+	if filter.PropertyID != "" {
+		conds.add("m.property_id = %s", filter.PropertyID)
+	}
+	if filter.VendorID != "" {
+		conds.add("m.vendor_id = %s", filter.VendorID)
+	}
+	if filter.Status != "" {
+		conds.add("m.status = %s", filter.Status)
+	}
+	if filter.Priority != "" {
+		conds.add("m.priority = %s", filter.Priority)
+	}
+	if filter.Category != "" {
+		conds.add("m.category = %s", filter.Category)
+	}
+	scopeOrganization(ctx, conds, "m.organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, selectMaintenance+conds.where()+`
+		ORDER BY m.created_at DESC
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanMaintenanceRequests(rows)
+}
+
+// GetByTenantID returns the requests a tenant reported, newest first.
+func (s *MaintenanceStore) GetByTenantID(ctx context.Context, tenantID string) ([]model.MaintenanceRequest, error) {
+	conds := newConditions()
+	conds.add("m.tenant_id = %s", tenantID)
+	scopeOrganization(ctx, conds, "m.organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, selectMaintenance+conds.where()+`
+		ORDER BY m.created_at DESC
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanMaintenanceRequests(rows)
+}
+
+func (s *MaintenanceStore) GetByID(ctx context.Context, id string) (model.MaintenanceRequest, error) {
+	conds := newConditions()
+	conds.add("m.id = %s", id)
+	scopeOrganization(ctx, conds, "m.organization_id")
+
+	m, err := scanMaintenanceRequest(conn(ctx, s.db).QueryRow(ctx, selectMaintenance+conds.where(), conds.args...))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.MaintenanceRequest{}, ErrNotFound
+	}
+	return m, err
+}
+
+func (s *MaintenanceStore) Create(ctx context.Context, m model.MaintenanceRequest) (model.MaintenanceRequest, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO maintenance_requests (`+maintenanceColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`, m.ID, m.OrganizationID, m.PropertyID, m.LeaseID, m.TenantID, m.VendorID, m.Title, m.Description, m.Priority, m.Category,
+		m.Status, m.ResolvedAt, m.ClosedAt, m.CreatedAt, m.UpdatedAt)
+
+	return m, err
+}
+
+func (s *MaintenanceStore) Update(ctx context.Context, m model.MaintenanceRequest) (model.MaintenanceRequest, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE maintenance_requests
+		SET tenant_id = $2, vendor_id = $3, title = $4, description = $5, priority = $6, category = $7,
+			status = $8, resolved_at = $9, closed_at = $10, updated_at = $11
+		WHERE id = $1
+	`, m.ID, m.TenantID, m.VendorID, m.Title, m.Description, m.Priority, m.Category,
+		m.Status, m.ResolvedAt, m.ClosedAt, m.UpdatedAt)
+
+	if err != nil {
+		return model.MaintenanceRequest{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.MaintenanceRequest{}, ErrNotFound
+	}
+	return m, nil
+}
+
+func (s *MaintenanceStore) Delete(ctx context.Context, id string) error {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM maintenance_requests `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetComments returns the comments on a request, oldest first.
+func (s *MaintenanceStore) GetComments(ctx context.Context, requestID string) ([]model.MaintenanceComment, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT c.id, c.request_id, c.author_id, COALESCE(u.name, ''), c.body, c.created_at
+		FROM maintenance_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.request_id = $1
+		ORDER BY c.created_at
+	`, requestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []model.MaintenanceComment{}
+	for rows.Next() {
+		var c model.MaintenanceComment
+		if err := rows.Scan(&c.ID, &c.RequestID, &c.AuthorID, &c.AuthorName, &c.Body, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+
+	return comments, rows.Err()
+}
+
+func (s *MaintenanceStore) CreateComment(ctx context.Context, c model.MaintenanceComment) (model.MaintenanceComment, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO maintenance_comments (id, request_id, author_id, body, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, c.ID, c.RequestID, c.AuthorID, c.Body, c.CreatedAt)
+
+	return c, err
+}
+
+func scanMaintenanceRequests(rows pgx.Rows) ([]model.MaintenanceRequest, error) {
+	defer rows.Close()
+
+	requests := []model.MaintenanceRequest{}
+	for rows.Next() {
+		m, err := scanMaintenanceRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, m)
+	}
+
+	return requests, rows.Err()
+}
+
+func scanMaintenanceRequest(row pgx.Row) (model.MaintenanceRequest, error) {
+	var m model.MaintenanceRequest
+	err := row.Scan(&m.ID, &m.OrganizationID, &m.PropertyID, &m.LeaseID, &m.TenantID, &m.VendorID, &m.Title, &m.Description, &m.Priority, &m.Category,
+		&m.Status, &m.TotalCost, &m.ResolvedAt, &m.ClosedAt, &m.CreatedAt, &m.UpdatedAt)
+	return m, err
+}
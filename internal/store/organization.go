@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type OrganizationStore struct {
+	db *pgxpool.Pool
+}
+
+func NewOrganizationStore(db *pgxpool.Pool) *OrganizationStore {
+	return &OrganizationStore{db: db}
+}
+
+func (s *OrganizationStore) GetAll(ctx context.Context) ([]model.Organization, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT id, name, created_at, updated_at
+		FROM organizations
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var organizations []model.Organization
+	for rows.Next() {
+		var o model.Organization
+		if err := rows.Scan(&o.ID, &o.Name, &o.CreatedAt, &o.UpdatedAt); err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, o)
+	}
+
+	return organizations, rows.Err()
+}
+
+func (s *OrganizationStore) GetByID(ctx context.Context, id string) (model.Organization, error) {
+	var o model.Organization
+	err := conn(ctx, s.db).QueryRow(ctx, `
+		SELECT id, name, created_at, updated_at
+		FROM organizations
+		WHERE id = $1
+	`, id).Scan(&o.ID, &o.Name, &o.CreatedAt, &o.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Organization{}, ErrNotFound
+	}
+	return o, err
+}
+
+func (s *OrganizationStore) Create(ctx context.Context, o model.Organization) (model.Organization, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO organizations (id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+	`, o.ID, o.Name, o.CreatedAt, o.UpdatedAt)
+
+	return o, err
+}
@@ -0,0 +1,207 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+const ownerColumns = `id, organization_id, user_id, name, email, phone,
+	street, unit, city, region, postal_code, country, management_fee_percent, created_at, updated_at`
+
+type OwnerStore struct {
+	db *pgxpool.Pool
+}
+
+func NewOwnerStore(db *pgxpool.Pool) *OwnerStore {
+	return &OwnerStore{db: db}
+}
+
+func (s *OwnerStore) GetAll(ctx context.Context) ([]model.Owner, error) {
+	conds := newConditions()
+	scopeOwners(ctx, conds)
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+ownerColumns+`
+		FROM owners
+		`+conds.where()+`
+		ORDER BY name
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var owners []model.Owner
+	for rows.Next() {
+		o, err := scanOwner(rows)
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, o)
+	}
+
+	return owners, rows.Err()
+}
+
+func (s *OwnerStore) GetByID(ctx context.Context, id string) (model.Owner, error) {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOwners(ctx, conds)
+
+	o, err := scanOwner(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+ownerColumns+`
+		FROM owners
+		`+conds.where(), conds.args...))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Owner{}, ErrNotFound
+	}
+	return o, err
+}
+
+// Create inserts an owner. It returns ErrDuplicate if the user account is
+// already another owner's.
+func (s *OwnerStore) Create(ctx context.Context, o model.Owner) (model.Owner, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO owners (`+ownerColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`, o.ID, o.OrganizationID, o.UserID, o.Name, o.Email, o.Phone,
+		o.Address.Street, o.Address.Unit, o.Address.City, o.Address.Region, o.Address.PostalCode, o.Address.Country,
+		o.ManagementFeePercent, o.CreatedAt, o.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return model.Owner{}, ErrDuplicate
+	}
+	return o, err
+}
+
+func (s *OwnerStore) Update(ctx context.Context, o model.Owner) (model.Owner, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE owners
+		SET user_id = $2, name = $3, email = $4, phone = $5,
+			street = $6, unit = $7, city = $8, region = $9, postal_code = $10, country = $11,
+			management_fee_percent = $12, updated_at = $13
+		WHERE id = $1
+	`, o.ID, o.UserID, o.Name, o.Email, o.Phone,
+		o.Address.Street, o.Address.Unit, o.Address.City, o.Address.Region, o.Address.PostalCode, o.Address.Country,
+		o.ManagementFeePercent, o.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return model.Owner{}, ErrDuplicate
+	}
+	if err != nil {
+		return model.Owner{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.Owner{}, ErrNotFound
+	}
+	return o, nil
+}
+
+func (s *OwnerStore) Delete(ctx context.Context, id string) error {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM owners `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetFeeRate records the owner's management fee percent from the given
+// day on, replacing any rate already recorded for that day.
+func (s *OwnerStore) SetFeeRate(ctx context.Context, ownerID string, effectiveFrom time.Time, percent float64) error {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO owner_fee_rates (owner_id, effective_from, fee_percent)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (owner_id, effective_from) DO UPDATE SET fee_percent = EXCLUDED.fee_percent
+	`, ownerID, effectiveFrom, percent)
+	return err
+}
+
+// FeeRateOn returns the management fee percent in effect for the owner on
+// the given day. Days before the owner's first recorded rate fall under
+// that first rate. It returns ErrNotFound if no rate is recorded at all.
+func (s *OwnerStore) FeeRateOn(ctx context.Context, ownerID string, day time.Time) (float64, error) {
+	var percent float64
+	err := conn(ctx, s.db).QueryRow(ctx, `
+		SELECT fee_percent
+		FROM owner_fee_rates
+		WHERE owner_id = $1
+		ORDER BY effective_from <= $2 DESC,
+			CASE WHEN effective_from <= $2 THEN effective_from END DESC,
+			effective_from
+		LIMIT 1
+	`, ownerID, day).Scan(&percent)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	return percent, err
+}
+
+// StatementLines returns, for each of the owner's properties, the rent
+// collected and the expenses incurred between start and end inclusive.
+// Rent counts as collected on the day it was paid. The fee and net columns
+// are left for the caller to work out.
+func (s *OwnerStore) StatementLines(ctx context.Context, ownerID string, start, end time.Time) ([]model.OwnerStatementLine, error) {
+	conds := newConditions(start, end)
+	conds.add("p.owner_id = %s", ownerID)
+	scopeOrganization(ctx, conds, "p.organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT p.id, concat_ws(', ', p.street, NULLIF(p.unit, '')),
+			COALESCE((
+				SELECT SUM(pay.amount)
+				FROM payments pay
+				JOIN leases l ON l.id = pay.lease_id
+				WHERE l.property_id = p.id AND pay.paid_at BETWEEN $1 AND $2
+			), 0),
+			COALESCE((
+				SELECT SUM(e.amount)
+				FROM property_expenses e
+				WHERE e.property_id = p.id AND e.incurred_on BETWEEN $1 AND $2
+			), 0)
+		FROM properties p
+		`+conds.where()+`
+		ORDER BY p.street, p.unit, p.id
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []model.OwnerStatementLine{}
+	for rows.Next() {
+		var l model.OwnerStatementLine
+		if err := rows.Scan(&l.PropertyID, &l.Property, &l.RentCollected, &l.Expenses); err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
+
+func scanOwner(row pgx.Row) (model.Owner, error) {
+	var o model.Owner
+	err := row.Scan(&o.ID, &o.OrganizationID, &o.UserID, &o.Name, &o.Email, &o.Phone,
+		&o.Address.Street, &o.Address.Unit, &o.Address.City, &o.Address.Region, &o.Address.PostalCode, &o.Address.Country,
+		&o.ManagementFeePercent, &o.CreatedAt, &o.UpdatedAt)
+	return o, err
+}
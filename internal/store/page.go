@@ -0,0 +1,106 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort field")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// sortColumn is a field a listing can be ordered by. Rows are paged by
+// keyset on (expr, id), so value must render the field of an item in a form
+// Postgres accepts as cast.
+type sortColumn[T any] struct {
+	expr  string
+	cast  string
+	value func(T) string
+}
+
+// cursor is the position after the last item of a page. Sort ties the
+// cursor to the ordering it was issued for.
+type cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// keyset resolves the sort column of the page and, if the request carries a
+// cursor, adds the condition that skips every row up to it. It returns the
+// column and the ORDER BY and LIMIT clause to append to the query, which
+// fetches one extra row so the caller can tell whether another page follows.
+func keyset[T any](conds *conditions, columns map[string]sortColumn[T], idExpr string, page model.PageRequest) (sortColumn[T], string, error) {
+	col, ok := columns[page.Sort]
+	if !ok {
+		return sortColumn[T]{}, "", ErrInvalidSort
+	}
+
+	direction, cmp := "ASC", ">"
+	if page.Desc {
+		direction, cmp = "DESC", "<"
+	}
+
+	if page.Cursor != "" {
+		c, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return sortColumn[T]{}, "", err
+		}
+		if c.Sort != page.Sort || c.Desc != page.Desc {
+			return sortColumn[T]{}, "", ErrInvalidCursor
+		}
+		conds.add(fmt.Sprintf("(%s, %s) %s (%%s::%s, %%s)", col.expr, idExpr, cmp, col.cast), c.Value, c.ID)
+	}
+
+	order := fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT %d", col.expr, direction, idExpr, direction, page.Limit+1)
+	return col, order, nil
+}
+
+// newPage trims the extra row fetched by keyset and builds the cursor for
+// the following page from the last item kept.
+func newPage[T any](items []T, total int, col sortColumn[T], id func(T) string, page model.PageRequest) model.Page[T] {
+	result := model.Page[T]{Items: items, Total: total}
+	if len(items) > page.Limit {
+		result.Items = items[:page.Limit]
+		last := result.Items[page.Limit-1]
+		next := encodeCursor(cursor{Sort: page.Sort, Desc: page.Desc, Value: col.value(last), ID: id(last)})
+		result.NextCursor = &next
+	}
+	if result.Items == nil {
+		result.Items = []T{}
+	}
+	return result
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
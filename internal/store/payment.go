@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type PaymentStore struct {
+	db *pgxpool.Pool
+}
+
+func NewPaymentStore(db *pgxpool.Pool) *PaymentStore {
+	return &PaymentStore{db: db}
+}
+
+func (s *PaymentStore) GetByID(ctx context.Context, id string) (model.Payment, error) {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeLeaseOrganization(ctx, conds, "payments.lease_id")
+
+	var p model.Payment
+	err := conn(ctx, s.db).QueryRow(ctx, `
+		SELECT id, lease_id, amount, paid_at, method, reference, created_at
+		FROM payments
+		`+conds.where(), conds.args...).Scan(&p.ID, &p.LeaseID, &p.Amount, &p.PaidAt, &p.Method, &p.Reference, &p.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Payment{}, ErrNotFound
+	}
+	return p, err
+}
+
+func (s *PaymentStore) GetByLeaseID(ctx context.Context, leaseID string) ([]model.Payment, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT id, lease_id, amount, paid_at, method, reference, created_at
+		FROM payments
+		WHERE lease_id = $1
+		ORDER BY paid_at, created_at
+	`, leaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []model.Payment
+	for rows.Next() {
+		var p model.Payment
+		err := rows.Scan(&p.ID, &p.LeaseID, &p.Amount, &p.PaidAt, &p.Method, &p.Reference, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+
+	return payments, nil
+}
+
+func (s *PaymentStore) Create(ctx context.Context, p model.Payment) (model.Payment, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO payments (id, lease_id, amount, paid_at, method, reference, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, p.ID, p.LeaseID, p.Amount, p.PaidAt, p.Method, p.Reference, p.CreatedAt)
+
+	return p, err
+}
+
+func (s *PaymentStore) Delete(ctx context.Context, id string) error {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeLeaseOrganization(ctx, conds, "payments.lease_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM payments `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
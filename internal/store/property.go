@@ -3,17 +3,41 @@ package store
 import (
 	"context"
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/Lacsw/rntly/internal/model"
 )
 
 var (
-	ErrNotFound = errors.New("not found")
+	ErrNotFound  = errors.New("not found")
+	ErrDuplicate = errors.New("duplicate")
+	ErrInUse     = errors.New("still referenced")
 )
 
+// propertySelect derives each property's status from the lease covering the
+// date passed as $1: a property is occupied on a day if some lease's date
+// range includes it, regardless of that lease's lifecycle status.
+const propertySelect = `
+	SELECT p.id, p.organization_id, p.building_id, p.unit_number, p.owner_id,
+		p.street, p.unit, p.city, p.region, p.postal_code, p.country, p.type, p.bedrooms,
+		p.bathrooms, p.area, p.floor, p.furnished, p.parking_spaces, p.pet_policy, p.amenities, p.rent_amount,
+		CASE WHEN cur.id IS NULL THEN 'vacant' ELSE 'occupied' END,
+		cur.id, cur.tenant_id, p.created_at, p.updated_at
+	FROM properties p
+	LEFT JOIN LATERAL (
+		SELECT l.id, l.tenant_id
+		FROM leases l
+		WHERE l.property_id = p.id AND l.start_date <= $1 AND l.end_date >= $1
+		ORDER BY l.start_date DESC
+		LIMIT 1
+	) cur ON true
+`
+
 type PropertyStore struct {
 	db *pgxpool.Pool
 }
@@ -22,37 +46,136 @@ func NewPropertyStore(db *pgxpool.Pool) *PropertyStore {
 	return &PropertyStore{db: db}
 }
 
-func (s *PropertyStore) GetAll(ctx context.Context) ([]model.Property, error) {
-	rows, err := s.db.Query(ctx, `
-		SELECT id, address, type, bedrooms, rent_amount, status, created_at, updated_at
-		FROM properties
-		ORDER BY created_at DESC
-	`)
+// propertySortColumns are the fields a property listing can be sorted by.
+var propertySortColumns = map[string]sortColumn[model.Property]{
+	"created_at":  {"p.created_at", "timestamp", func(p model.Property) string { return formatTime(p.CreatedAt) }},
+	"updated_at":  {"p.updated_at", "timestamp", func(p model.Property) string { return formatTime(p.UpdatedAt) }},
+	"rent_amount": {"p.rent_amount", "numeric", func(p model.Property) string { return formatFloat(p.RentAmount) }},
+	"bedrooms":    {"p.bedrooms", "integer", func(p model.Property) string { return strconv.Itoa(p.Bedrooms) }},
+	"city":        {"p.city", "text", func(p model.Property) string { return p.Address.City }},
+}
+
+// List returns one page of the properties matching the filter, with their
+// occupancy as of the given date.
+func (s *PropertyStore) List(ctx context.Context, asOf time.Time, filter model.PropertyFilter, page model.PageRequest) (model.Page[model.Property], error) {
+	conds := newConditions(asOf)
+	scopeProperties(ctx, conds)
+	if filter.Status == "occupied" {
+		conds.add("cur.id IS NOT NULL")
+	}
+	if filter.Status == "vacant" {
+		conds.add("cur.id IS NULL")
+	}
+	if filter.BuildingID != "" {
+		conds.add("p.building_id = %s", filter.BuildingID)
+	}
+	if filter.OwnerID != "" {
+		conds.add("p.owner_id = %s", filter.OwnerID)
+	}
+	if filter.City != "" {
+		conds.add("LOWER(p.city) = LOWER(%s)", filter.City)
+	}
+	if filter.PostalCode != "" {
+		conds.add("p.postal_code LIKE %s || '%'", filter.PostalCode)
+	}
+	if filter.Type != "" {
+		conds.add("p.type = %s", filter.Type)
+	}
+	if filter.MinBedrooms != nil {
+		conds.add("p.bedrooms >= %s", *filter.MinBedrooms)
+	}
+	if filter.MaxBedrooms != nil {
+		conds.add("p.bedrooms <= %s", *filter.MaxBedrooms)
+	}
+	if filter.MinBathrooms != nil {
+		conds.add("p.bathrooms >= %s", *filter.MinBathrooms)
+	}
+	if filter.MinArea != nil {
+		conds.add("p.area >= %s", *filter.MinArea)
+	}
+	if filter.MaxArea != nil {
+		conds.add("p.area <= %s", *filter.MaxArea)
+	}
+	if filter.MinRent != nil {
+		conds.add("p.rent_amount >= %s", *filter.MinRent)
+	}
+	if filter.MaxRent != nil {
+		conds.add("p.rent_amount <= %s", *filter.MaxRent)
+	}
+	if filter.Furnished != nil {
+		conds.add("p.furnished = %s", *filter.Furnished)
+	}
+	if filter.HasParking != nil {
+		conds.add("(p.parking_spaces > 0) = %s", *filter.HasParking)
+	}
+	if filter.PetPolicy != "" {
+		conds.add("p.pet_policy = %s", filter.PetPolicy)
+	}
+	if len(filter.Amenities) > 0 {
+		conds.add("p.amenities @> %s", filter.Amenities)
+	}
+
+	var total int
+	err := conn(ctx, s.db).QueryRow(ctx, `SELECT COUNT(*) FROM (`+propertySelect+conds.where()+`) matched`, conds.args...).Scan(&total)
 	if err != nil {
-		return nil, err
+		return model.Page[model.Property]{}, err
 	}
-	defer rows.Close()
 
-	var properties []model.Property
-	for rows.Next() {
-		var p model.Property
-		err := rows.Scan(&p.ID, &p.Address, &p.Type, &p.Bedrooms, &p.RentAmount, &p.Status, &p.CreatedAt, &p.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		properties = append(properties, p)
+	col, order, err := keyset(conds, propertySortColumns, "p.id", page)
+	if err != nil {
+		return model.Page[model.Property]{}, err
 	}
 
-	return properties, nil
+	properties, err := s.query(ctx, propertySelect+conds.where()+order, conds.args...)
+	if err != nil {
+		return model.Page[model.Property]{}, err
+	}
+
+	return newPage(properties, total, col, func(p model.Property) string { return p.ID }, page), nil
 }
 
+// GetByBuildingID returns the units of a building with their occupancy as
+// of the given date.
+func (s *PropertyStore) GetByBuildingID(ctx context.Context, buildingID string, asOf time.Time) ([]model.Property, error) {
+	conds := newConditions(asOf)
+	conds.add("p.building_id = %s", buildingID)
+	scopeProperties(ctx, conds)
+
+	return s.query(ctx, propertySelect+conds.where()+`
+		ORDER BY p.unit_number
+	`, conds.args...)
+}
+
+// GetByOwnerID returns the properties managed for an owner with their
+// occupancy as of the given date.
+func (s *PropertyStore) GetByOwnerID(ctx context.Context, ownerID string, asOf time.Time) ([]model.Property, error) {
+	conds := newConditions(asOf)
+	conds.add("p.owner_id = %s", ownerID)
+	scopeProperties(ctx, conds)
+
+	return s.query(ctx, propertySelect+conds.where()+`
+		ORDER BY p.street, p.unit, p.id
+	`, conds.args...)
+}
+
+// GetByID returns a property with its occupancy as of today.
 func (s *PropertyStore) GetByID(ctx context.Context, id string) (model.Property, error) {
-	var p model.Property
-	err := s.db.QueryRow(ctx, `
-		SELECT id, address, type, bedrooms, rent_amount, status, created_at, updated_at
-		FROM properties
-		WHERE id = $1
-	`, id).Scan(&p.ID, &p.Address, &p.Type, &p.Bedrooms, &p.RentAmount, &p.Status, &p.CreatedAt, &p.UpdatedAt)
+	return s.GetByIDAsOf(ctx, id, time.Now().UTC())
+}
+
+// Lock takes a row lock on the property until the transaction in ctx
+// ends, so that checks across its leases are not raced by another writer.
+func (s *PropertyStore) Lock(ctx context.Context, id string) error {
+	_, err := conn(ctx, s.db).Exec(ctx, `SELECT 1 FROM properties WHERE id = $1 FOR UPDATE`, id)
+	return err
+}
+
+func (s *PropertyStore) GetByIDAsOf(ctx context.Context, id string, asOf time.Time) (model.Property, error) {
+	conds := newConditions(asOf)
+	conds.add("p.id = %s", id)
+	scopeProperties(ctx, conds)
+
+	p, err := scanProperty(conn(ctx, s.db).QueryRow(ctx, propertySelect+conds.where(), conds.args...))
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return model.Property{}, ErrNotFound
@@ -61,21 +184,37 @@ func (s *PropertyStore) GetByID(ctx context.Context, id string) (model.Property,
 }
 
 func (s *PropertyStore) Create(ctx context.Context, p model.Property) (model.Property, error) {
-	_, err := s.db.Exec(ctx, `
-		INSERT INTO properties (id, address, type, bedrooms, rent_amount, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	`, p.ID, p.Address, p.Type, p.Bedrooms, p.RentAmount, p.Status, p.CreatedAt, p.UpdatedAt)
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO properties (id, organization_id, building_id, unit_number, owner_id, street, unit, city, region, postal_code, country,
+			type, bedrooms, bathrooms, area, floor, furnished, parking_spaces, pet_policy, amenities,
+			rent_amount, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+	`, p.ID, p.OrganizationID, p.BuildingID, p.UnitNumber, p.OwnerID, p.Address.Street, p.Address.Unit, p.Address.City, p.Address.Region, p.Address.PostalCode, p.Address.Country,
+		p.Type, p.Bedrooms, p.Bathrooms, p.Area, p.Floor, p.Furnished, p.ParkingSpaces, p.PetPolicy, p.Amenities,
+		p.RentAmount, p.CreatedAt, p.UpdatedAt)
 
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return model.Property{}, ErrDuplicate
+	}
 	return p, err
 }
 
 func (s *PropertyStore) Update(ctx context.Context, p model.Property) (model.Property, error) {
-	result, err := s.db.Exec(ctx, `
+	result, err := conn(ctx, s.db).Exec(ctx, `
 		UPDATE properties
-		SET address = $2, type = $3, bedrooms = $4, rent_amount = $5, status = $6, updated_at = $7
+		SET building_id = $2, unit_number = $3, owner_id = $4, street = $5, unit = $6, city = $7, region = $8, postal_code = $9,
+			country = $10, type = $11, bedrooms = $12, bathrooms = $13, area = $14, floor = $15, furnished = $16, parking_spaces = $17,
+			pet_policy = $18, amenities = $19, rent_amount = $20, updated_at = $21
 		WHERE id = $1
-	`, p.ID, p.Address, p.Type, p.Bedrooms, p.RentAmount, p.Status, p.UpdatedAt)
+	`, p.ID, p.BuildingID, p.UnitNumber, p.OwnerID, p.Address.Street, p.Address.Unit, p.Address.City, p.Address.Region, p.Address.PostalCode, p.Address.Country,
+		p.Type, p.Bedrooms, p.Bathrooms, p.Area, p.Floor, p.Furnished, p.ParkingSpaces, p.PetPolicy, p.Amenities,
+		p.RentAmount, p.UpdatedAt)
 
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return model.Property{}, ErrDuplicate
+	}
 	if err != nil {
 		return model.Property{}, err
 	}
@@ -86,9 +225,11 @@ func (s *PropertyStore) Update(ctx context.Context, p model.Property) (model.Pro
 }
 
 func (s *PropertyStore) Delete(ctx context.Context, id string) error {
-	result, err := s.db.Exec(ctx, `
-		DELETE FROM properties WHERE id = $1
-	`, id)
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM properties `+conds.where(), conds.args...)
 
 	if err != nil {
 		return err
@@ -98,3 +239,30 @@ func (s *PropertyStore) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func (s *PropertyStore) query(ctx context.Context, sql string, args ...any) ([]model.Property, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var properties []model.Property
+	for rows.Next() {
+		p, err := scanProperty(rows)
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, p)
+	}
+
+	return properties, rows.Err()
+}
+
+func scanProperty(row pgx.Row) (model.Property, error) {
+	var p model.Property
+	err := row.Scan(&p.ID, &p.OrganizationID, &p.BuildingID, &p.UnitNumber, &p.OwnerID,
+		&p.Address.Street, &p.Address.Unit, &p.Address.City, &p.Address.Region, &p.Address.PostalCode, &p.Address.Country, &p.Type, &p.Bedrooms,
+		&p.Bathrooms, &p.Area, &p.Floor, &p.Furnished, &p.ParkingSpaces, &p.PetPolicy, &p.Amenities, &p.RentAmount, &p.Status, &p.CurrentLeaseID, &p.CurrentTenantID, &p.CreatedAt, &p.UpdatedAt)
+	return p, err
+}
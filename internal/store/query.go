@@ -0,0 +1,38 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// conditions accumulates optional WHERE conditions and their arguments for
+// queries whose filters depend on the request. Arguments already bound by
+// the base query are passed to newConditions so placeholders keep counting
+// from there.
+type conditions struct {
+	clauses []string
+	args    []any
+}
+
+func newConditions(args ...any) *conditions {
+	return &conditions{args: args}
+}
+
+// add appends a condition. Each %s in clause is replaced, in order, by the
+// placeholder of the corresponding argument.
+func (c *conditions) add(clause string, args ...any) {
+	for _, arg := range args {
+		c.args = append(c.args, arg)
+		clause = strings.Replace(clause, "%s", fmt.Sprintf("$%d", len(c.args)), 1)
+	}
+	c.clauses = append(c.clauses, clause)
+}
+
+// where renders the conditions as a WHERE clause, or an empty string if
+// there are none.
+func (c *conditions) where() string {
+	if len(c.clauses) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(c.clauses, " AND ")
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+
+	"github.com/Lacsw/rntly/internal/auth"
+)
+
+// The scope functions add the conditions that limit a query to the records
+// the signed-in user in ctx may see: those of their organization and, for
+// owners and tenants, only the ones that concern them. They add nothing
+// for background work, which runs without a user.
+
+// scopeOrganization limits a table with its own organization_id column,
+// passed qualified as the query needs it.
+func scopeOrganization(ctx context.Context, conds *conditions, column string) {
+	if org := auth.ScopeFrom(ctx).OrganizationID; org != "" {
+		conds.add(column+" = %s", org)
+	}
+}
+
+// scopeLeaseOrganization limits rows that belong to a lease, such as
+// payments and invoices, to the leases of the user's organization.
+// leaseColumn is the column holding the lease id.
+func scopeLeaseOrganization(ctx context.Context, conds *conditions, leaseColumn string) {
+	if org := auth.ScopeFrom(ctx).OrganizationID; org != "" {
+		conds.add(`EXISTS (
+			SELECT 1 FROM leases ol
+			WHERE ol.id = `+leaseColumn+` AND ol.organization_id = %s)`, org)
+	}
+}
+
+// scopePropertyOrganization limits rows that belong to a property, such as
+// late fee policies, to the properties of the user's organization.
+// propertyColumn is the column holding the property id.
+func scopePropertyOrganization(ctx context.Context, conds *conditions, propertyColumn string) {
+	if org := auth.ScopeFrom(ctx).OrganizationID; org != "" {
+		conds.add(`EXISTS (
+			SELECT 1 FROM properties op
+			WHERE op.id = `+propertyColumn+` AND op.organization_id = %s)`, org)
+	}
+}
+
+// scopeProperties limits properties, aliased p.
+func scopeProperties(ctx context.Context, conds *conditions) {
+	scopeOrganization(ctx, conds, "p.organization_id")
+
+	scope := auth.ScopeFrom(ctx)
+	switch scope.Role {
+	case auth.RoleOwner:
+		conds.add(`EXISTS (
+			SELECT 1 FROM owners so
+			WHERE so.id = p.owner_id AND so.user_id = %s)`, scope.UserID)
+	case auth.RoleTenant:
+		conds.add(`EXISTS (
+			SELECT 1 FROM leases sl JOIN lease_parties slp ON slp.lease_id = sl.id
+			WHERE sl.property_id = p.id AND slp.tenant_id = %s)`, scope.TenantID)
+	}
+}
+
+// scopeLeases limits leases, referenced by the unaliased table name.
+func scopeLeases(ctx context.Context, conds *conditions) {
+	scopeOrganization(ctx, conds, "leases.organization_id")
+
+	scope := auth.ScopeFrom(ctx)
+	switch scope.Role {
+	case auth.RoleOwner:
+		conds.add(`EXISTS (
+			SELECT 1 FROM properties sp JOIN owners so ON so.id = sp.owner_id
+			WHERE sp.id = leases.property_id AND so.user_id = %s)`, scope.UserID)
+	case auth.RoleTenant:
+		conds.add(`EXISTS (
+			SELECT 1 FROM lease_parties slp
+			WHERE slp.lease_id = leases.id AND slp.tenant_id = %s)`, scope.TenantID)
+	}
+}
+
+// scopeTenants limits tenants, referenced by the unaliased table name.
+// Owners see the tenants on leases of their properties; a tenant sees only
+// themselves.
+func scopeTenants(ctx context.Context, conds *conditions) {
+	scopeOrganization(ctx, conds, "tenants.organization_id")
+
+	scope := auth.ScopeFrom(ctx)
+	switch scope.Role {
+	case auth.RoleOwner:
+		conds.add(`EXISTS (
+			SELECT 1 FROM lease_parties slp
+			JOIN leases sl ON sl.id = slp.lease_id
+			JOIN properties sp ON sp.id = sl.property_id
+			JOIN owners so ON so.id = sp.owner_id
+			WHERE slp.tenant_id = tenants.id AND so.user_id = %s)`, scope.UserID)
+	case auth.RoleTenant:
+		conds.add("tenants.id = %s", scope.TenantID)
+	}
+}
+
+// scopeOwners limits owners, referenced by the unaliased table name. An
+// owner sees only their own record.
+func scopeOwners(ctx context.Context, conds *conditions) {
+	scopeOrganization(ctx, conds, "owners.organization_id")
+
+	scope := auth.ScopeFrom(ctx)
+	if scope.Role == auth.RoleOwner {
+		conds.add("owners.user_id = %s", scope.UserID)
+	}
+}
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+// searchQuery selects the matches of one entity type against the tsquery
+// in $1. match is the condition a row must meet and organizationColumn the
+// column the organization scope applies to.
+type searchQuery struct {
+	query              string
+	match              string
+	organizationColumn string
+	orderBy            string
+}
+
+// searchQueries are the queries for each searchable entity type. A lease
+// matches on its property together with any of its parties, so "smith elm"
+// finds the Smith lease on Elm Street.
+var searchQueries = map[string]searchQuery{
+	"tenant": {
+		query: `
+			SELECT 'tenant', t.id, t.first_name || ' ' || t.last_name, t.email,
+				ts_rank(t.search_vector, to_tsquery('simple', $1))::float8
+			FROM tenants t`,
+		match:              "t.search_vector @@ to_tsquery('simple', $1)",
+		organizationColumn: "t.organization_id",
+	},
+	"property": {
+		query: `
+			SELECT 'property', p.id, concat_ws(', ', p.street, NULLIF(p.unit, '')), concat_ws(' ', NULLIF(p.postal_code, ''), NULLIF(p.city, '')),
+				ts_rank(p.search_vector, to_tsquery('simple', $1))::float8
+			FROM properties p`,
+		match:              "p.search_vector @@ to_tsquery('simple', $1)",
+		organizationColumn: "p.organization_id",
+	},
+	"lease": {
+		query: `
+			SELECT DISTINCT ON (l.id) 'lease', l.id,
+				pt.first_name || ' ' || pt.last_name || ', ' || concat_ws(', ', p.street, NULLIF(p.unit, '')),
+				to_char(l.start_date, 'YYYY-MM-DD') || ' to ' || to_char(l.end_date, 'YYYY-MM-DD') || ' (' || l.status || ')',
+				ts_rank(t.search_vector || p.search_vector, to_tsquery('simple', $1))::float8
+			FROM leases l
+			JOIN properties p ON p.id = l.property_id
+			JOIN tenants pt ON pt.id = l.tenant_id
+			JOIN lease_parties lp ON lp.lease_id = l.id
+			JOIN tenants t ON t.id = lp.tenant_id`,
+		match:              "(t.search_vector || p.search_vector) @@ to_tsquery('simple', $1)",
+		organizationColumn: "l.organization_id",
+		orderBy:            "ORDER BY l.id, 5 DESC",
+	},
+}
+
+type SearchStore struct {
+	db *pgxpool.Pool
+}
+
+func NewSearchStore(db *pgxpool.Pool) *SearchStore {
+	return &SearchStore{db: db}
+}
+
+// Search returns the best matches for a tsquery across the given entity
+// types, highest rank first.
+func (s *SearchStore) Search(ctx context.Context, tsquery string, types []string, limit int) ([]model.SearchResult, error) {
+	// The parts share one argument list: $1 is the tsquery, $2 the limit
+	// and each part's scope appends its own.
+	args := []any{tsquery, limit}
+	var parts []string
+	for _, t := range types {
+		q := searchQueries[t]
+		conds := newConditions(args...)
+		conds.add(q.match)
+		scopeOrganization(ctx, conds, q.organizationColumn)
+		args = conds.args
+
+		parts = append(parts, "("+q.query+"\n"+conds.where()+"\n"+q.orderBy+")")
+	}
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT type, id, title, subtitle, rank
+		FROM (`+strings.Join(parts, " UNION ALL ")+`) AS results (type, id, title, subtitle, rank)
+		ORDER BY rank DESC, type, title
+		LIMIT $2
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []model.SearchResult{}
+	for rows.Next() {
+		var r model.SearchResult
+		if err := rows.Scan(&r.Type, &r.ID, &r.Title, &r.Subtitle, &r.Rank); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+
+	return results, rows.Err()
+}
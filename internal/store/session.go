@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+type SessionStore struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionStore(db *pgxpool.Pool) *SessionStore {
+	return &SessionStore{db: db}
+}
+
+func (s *SessionStore) Create(ctx context.Context, tokenHash, userID string, createdAt, expiresAt time.Time) error {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO sessions (token_hash, user_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, tokenHash, userID, createdAt, expiresAt)
+
+	return err
+}
+
+// GetUser returns the user a session belongs to, provided the session has
+// not expired by now.
+func (s *SessionStore) GetUser(ctx context.Context, tokenHash string, now time.Time) (model.User, error) {
+	u, err := scanUser(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT u.id, u.organization_id, u.email, u.name, u.role, u.tenant_id, u.password_hash, u.created_at, u.updated_at
+		FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.token_hash = $1 AND s.expires_at > $2
+	`, tokenHash, now))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.User{}, ErrNotFound
+	}
+	return u, err
+}
+
+func (s *SessionStore) Delete(ctx context.Context, tokenHash string) error {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM sessions WHERE token_hash = $1
+	`, tokenHash)
+
+	return err
+}
+
+// DeleteExpired removes every session that expired before now.
+func (s *SessionStore) DeleteExpired(ctx context.Context, now time.Time) error {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM sessions WHERE expires_at <= $1
+	`, now)
+
+	return err
+}
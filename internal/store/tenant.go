@@ -5,11 +5,15 @@ import (
 	"errors"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/Lacsw/rntly/internal/model"
 )
 
+const tenantColumns = `id, organization_id, first_name, last_name, email, phone,
+	street, unit, city, region, postal_code, country, created_at, updated_at`
+
 type TenantStore struct {
 	db *pgxpool.Pool
 }
@@ -18,37 +22,76 @@ func NewTenantStore(db *pgxpool.Pool) *TenantStore {
 	return &TenantStore{db: db}
 }
 
-func (s *TenantStore) GetAll(ctx context.Context) ([]model.Tenant, error) {
-	rows, err := s.db.Query(ctx, `
-		SELECT id, first_name, last_name, email, phone, created_at, updated_at
+// tenantSortColumns are the fields a tenant listing can be sorted by.
+var tenantSortColumns = map[string]sortColumn[model.Tenant]{
+	"created_at": {"created_at", "timestamp", func(t model.Tenant) string { return formatTime(t.CreatedAt) }},
+	"updated_at": {"updated_at", "timestamp", func(t model.Tenant) string { return formatTime(t.UpdatedAt) }},
+	"first_name": {"first_name", "text", func(t model.Tenant) string { return t.FirstName }},
+	"last_name":  {"last_name", "text", func(t model.Tenant) string { return t.LastName }},
+	"email":      {"email", "text", func(t model.Tenant) string { return t.Email }},
+}
+
+// List returns one page of the tenants matching the filter.
+func (s *TenantStore) List(ctx context.Context, filter model.TenantFilter, page model.PageRequest) (model.Page[model.Tenant], error) {
+	conds := newConditions()
+	scopeTenants(ctx, conds)
+	if filter.Name != "" {
+		conds.add("(first_name || ' ' || last_name) ILIKE '%' || %s || '%'", filter.Name)
+	}
+	if filter.Email != "" {
+		conds.add("LOWER(email) = LOWER(%s)", filter.Email)
+	}
+	if filter.City != "" {
+		conds.add("LOWER(city) = LOWER(%s)", filter.City)
+	}
+	if filter.PostalCode != "" {
+		conds.add("postal_code LIKE %s || '%'", filter.PostalCode)
+	}
+
+	var total int
+	err := conn(ctx, s.db).QueryRow(ctx, `SELECT COUNT(*) FROM tenants `+conds.where(), conds.args...).Scan(&total)
+	if err != nil {
+		return model.Page[model.Tenant]{}, err
+	}
+
+	col, order, err := keyset(conds, tenantSortColumns, "id", page)
+	if err != nil {
+		return model.Page[model.Tenant]{}, err
+	}
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+tenantColumns+`
 		FROM tenants
-		ORDER BY created_at DESC
-	`)
+		`+conds.where()+order, conds.args...)
 	if err != nil {
-		return nil, err
+		return model.Page[model.Tenant]{}, err
 	}
 	defer rows.Close()
 
 	var tenants []model.Tenant
 	for rows.Next() {
-		var t model.Tenant
-		err := rows.Scan(&t.ID, &t.FirstName, &t.LastName, &t.Email, &t.Phone, &t.CreatedAt, &t.UpdatedAt)
+		t, err := scanTenant(rows)
 		if err != nil {
-			return nil, err
+			return model.Page[model.Tenant]{}, err
 		}
 		tenants = append(tenants, t)
 	}
+	if err := rows.Err(); err != nil {
+		return model.Page[model.Tenant]{}, err
+	}
 
-	return tenants, nil
+	return newPage(tenants, total, col, func(t model.Tenant) string { return t.ID }, page), nil
 }
 
 func (s *TenantStore) GetByID(ctx context.Context, id string) (model.Tenant, error) {
-	var t model.Tenant
-	err := s.db.QueryRow(ctx, `
-		SELECT id, first_name, last_name, email, phone, created_at, updated_at
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeTenants(ctx, conds)
+
+	t, err := scanTenant(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+tenantColumns+`
 		FROM tenants
-		WHERE id = $1
-	`, id).Scan(&t.ID, &t.FirstName, &t.LastName, &t.Email, &t.Phone, &t.CreatedAt, &t.UpdatedAt)
+		`+conds.where(), conds.args...))
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return model.Tenant{}, ErrNotFound
@@ -57,20 +100,25 @@ func (s *TenantStore) GetByID(ctx context.Context, id string) (model.Tenant, err
 }
 
 func (s *TenantStore) Create(ctx context.Context, t model.Tenant) (model.Tenant, error) {
-	_, err := s.db.Exec(ctx, `
-		INSERT INTO tenants (id, first_name, last_name, email, phone, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`, t.ID, t.FirstName, t.LastName, t.Email, t.Phone, t.CreatedAt, t.UpdatedAt)
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO tenants (`+tenantColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`, t.ID, t.OrganizationID, t.FirstName, t.LastName, t.Email, t.Phone,
+		t.Address.Street, t.Address.Unit, t.Address.City, t.Address.Region, t.Address.PostalCode, t.Address.Country,
+		t.CreatedAt, t.UpdatedAt)
 
 	return t, err
 }
 
 func (s *TenantStore) Update(ctx context.Context, t model.Tenant) (model.Tenant, error) {
-	result, err := s.db.Exec(ctx, `
+	result, err := conn(ctx, s.db).Exec(ctx, `
 		UPDATE tenants
-		SET first_name = $2, last_name = $3, email = $4, phone = $5, updated_at = $6
+		SET first_name = $2, last_name = $3, email = $4, phone = $5,
+			street = $6, unit = $7, city = $8, region = $9, postal_code = $10, country = $11, updated_at = $12
 		WHERE id = $1
-	`, t.ID, t.FirstName, t.LastName, t.Email, t.Phone, t.UpdatedAt)
+	`, t.ID, t.FirstName, t.LastName, t.Email, t.Phone,
+		t.Address.Street, t.Address.Unit, t.Address.City, t.Address.Region, t.Address.PostalCode, t.Address.Country,
+		t.UpdatedAt)
 
 	if err != nil {
 		return model.Tenant{}, err
@@ -81,11 +129,19 @@ func (s *TenantStore) Update(ctx context.Context, t model.Tenant) (model.Tenant,
 	return t, nil
 }
 
+// Delete removes a tenant. It returns ErrInUse if the tenant is still a
+// party to a lease.
 func (s *TenantStore) Delete(ctx context.Context, id string) error {
-	result, err := s.db.Exec(ctx, `
-		DELETE FROM tenants WHERE id = $1
-	`, id)
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
 
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM tenants `+conds.where(), conds.args...)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return ErrInUse
+	}
 	if err != nil {
 		return err
 	}
@@ -94,3 +150,11 @@ func (s *TenantStore) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func scanTenant(row pgx.Row) (model.Tenant, error) {
+	var t model.Tenant
+	err := row.Scan(&t.ID, &t.OrganizationID, &t.FirstName, &t.LastName, &t.Email, &t.Phone,
+		&t.Address.Street, &t.Address.Unit, &t.Address.City, &t.Address.Region, &t.Address.PostalCode, &t.Address.Country,
+		&t.CreatedAt, &t.UpdatedAt)
+	return t, err
+}
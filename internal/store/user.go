@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+const userColumns = `id, organization_id, email, name, role, tenant_id, password_hash, created_at, updated_at`
+
+type UserStore struct {
+	db *pgxpool.Pool
+}
+
+func NewUserStore(db *pgxpool.Pool) *UserStore {
+	return &UserStore{db: db}
+}
+
+func (s *UserStore) GetAll(ctx context.Context) ([]model.User, error) {
+	conds := newConditions()
+	scopeOrganization(ctx, conds, "organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		`+conds.where()+`
+		ORDER BY email
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+func (s *UserStore) GetByID(ctx context.Context, id string) (model.User, error) {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	u, err := scanUser(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		`+conds.where(), conds.args...))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.User{}, ErrNotFound
+	}
+	return u, err
+}
+
+// GetByEmail looks a user up by email, ignoring case. Emails are unique
+// across organizations, so it is used to sign in before any is known.
+func (s *UserStore) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	u, err := scanUser(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE LOWER(email) = LOWER($1)
+	`, email))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.User{}, ErrNotFound
+	}
+	return u, err
+}
+
+func (s *UserStore) Count(ctx context.Context) (int, error) {
+	var n int
+	err := conn(ctx, s.db).QueryRow(ctx, `SELECT COUNT(*) FROM users`).Scan(&n)
+	return n, err
+}
+
+func (s *UserStore) Create(ctx context.Context, u model.User) (model.User, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO users (`+userColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, u.ID, u.OrganizationID, u.Email, u.Name, u.Role, u.TenantID, u.PasswordHash, u.CreatedAt, u.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return model.User{}, ErrDuplicate
+	}
+	return u, err
+}
+
+func (s *UserStore) Update(ctx context.Context, u model.User) (model.User, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE users
+		SET name = $2, role = $3, tenant_id = $4, updated_at = $5
+		WHERE id = $1
+	`, u.ID, u.Name, u.Role, u.TenantID, u.UpdatedAt)
+
+	if err != nil {
+		return model.User{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.User{}, ErrNotFound
+	}
+	return u, nil
+}
+
+func (s *UserStore) Delete(ctx context.Context, id string) error {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM users `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanUser(row pgx.Row) (model.User, error) {
+	var u model.User
+	err := row.Scan(&u.ID, &u.OrganizationID, &u.Email, &u.Name, &u.Role, &u.TenantID, &u.PasswordHash, &u.CreatedAt, &u.UpdatedAt)
+	return u, err
+}
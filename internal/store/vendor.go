@@ -0,0 +1,340 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Lacsw/rntly/internal/model"
+)
+
+const vendorColumns = `v.id, v.organization_id, v.name, v.trade, v.contact_name, v.email, v.phone, v.insurance_expires_on, v.hourly_rate,
+	v.created_at, v.updated_at`
+
+const vendorJobColumns = `id, vendor_id, property_id, maintenance_request_id, description, performed_on, hours, created_at`
+
+const vendorInvoiceColumns = `id, vendor_id, job_id, number, issued_on, due_on, amount, paid_on, created_at, updated_at`
+
+type VendorStore struct {
+	db *pgxpool.Pool
+}
+
+func NewVendorStore(db *pgxpool.Pool) *VendorStore {
+	return &VendorStore{db: db}
+}
+
+// GetAll returns the vendors matching the filter by name. Insurance counts
+// as lapsed if it expired before asOf.
+func (s *VendorStore) GetAll(ctx context.Context, filter model.VendorFilter, asOf time.Time) ([]model.Vendor, error) {
+	conds := newConditions()
+	if filter.Trade != "" {
+		conds.add("v.trade = %s", filter.Trade)
+	}
+	if filter.InsuranceLapsed != nil {
+		if *filter.InsuranceLapsed {
+			conds.add("v.insurance_expires_on < %s", asOf)
+		} else {
+			conds.add("(v.insurance_expires_on IS NULL OR v.insurance_expires_on >= %s)", asOf)
+		}
+	}
+	scopeOrganization(ctx, conds, "v.organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+vendorColumns+`
+		FROM vendors v
+		`+conds.where()+`
+		ORDER BY v.name
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vendors := []model.Vendor{}
+	for rows.Next() {
+		v, err := scanVendor(rows)
+		if err != nil {
+			return nil, err
+		}
+		vendors = append(vendors, v)
+	}
+
+	return vendors, rows.Err()
+}
+
+func (s *VendorStore) GetByID(ctx context.Context, id string) (model.Vendor, error) {
+	conds := newConditions()
+	conds.add("v.id = %s", id)
+	scopeOrganization(ctx, conds, "v.organization_id")
+
+	v, err := scanVendor(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+vendorColumns+`
+		FROM vendors v
+		`+conds.where(), conds.args...))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Vendor{}, ErrNotFound
+	}
+	return v, err
+}
+
+// GetByPropertyID returns the vendors that have done jobs at a property,
+// most recently active first.
+func (s *VendorStore) GetByPropertyID(ctx context.Context, propertyID string) ([]model.PropertyVendor, error) {
+	conds := newConditions(propertyID)
+	scopeOrganization(ctx, conds, "v.organization_id")
+
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+vendorColumns+`, j.jobs, j.last_job_on
+		FROM vendors v
+		JOIN (
+			SELECT vendor_id, COUNT(*) AS jobs, MAX(performed_on) AS last_job_on
+			FROM vendor_jobs
+			WHERE property_id = $1
+			GROUP BY vendor_id
+		) j ON j.vendor_id = v.id
+		`+conds.where()+`
+		ORDER BY j.last_job_on DESC, v.name
+	`, conds.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vendors := []model.PropertyVendor{}
+	for rows.Next() {
+		var pv model.PropertyVendor
+		v := &pv.Vendor
+		err := rows.Scan(&v.ID, &v.OrganizationID, &v.Name, &v.Trade, &v.ContactName, &v.Email, &v.Phone, &v.InsuranceExpiresOn, &v.HourlyRate,
+			&v.CreatedAt, &v.UpdatedAt, &pv.Jobs, &pv.LastJobOn)
+		if err != nil {
+			return nil, err
+		}
+		vendors = append(vendors, pv)
+	}
+
+	return vendors, rows.Err()
+}
+
+func (s *VendorStore) Create(ctx context.Context, v model.Vendor) (model.Vendor, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO vendors (id, organization_id, name, trade, contact_name, email, phone, insurance_expires_on, hourly_rate,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, v.ID, v.OrganizationID, v.Name, v.Trade, v.ContactName, v.Email, v.Phone, v.InsuranceExpiresOn, v.HourlyRate,
+		v.CreatedAt, v.UpdatedAt)
+
+	return v, err
+}
+
+func (s *VendorStore) Update(ctx context.Context, v model.Vendor) (model.Vendor, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE vendors
+		SET name = $2, trade = $3, contact_name = $4, email = $5, phone = $6, insurance_expires_on = $7, hourly_rate = $8,
+			updated_at = $9
+		WHERE id = $1
+	`, v.ID, v.Name, v.Trade, v.ContactName, v.Email, v.Phone, v.InsuranceExpiresOn, v.HourlyRate, v.UpdatedAt)
+
+	if err != nil {
+		return model.Vendor{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.Vendor{}, ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *VendorStore) Delete(ctx context.Context, id string) error {
+	conds := newConditions()
+	conds.add("id = %s", id)
+	scopeOrganization(ctx, conds, "organization_id")
+
+	result, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM vendors `+conds.where(), conds.args...)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetJobs returns a vendor's jobs, newest first.
+func (s *VendorStore) GetJobs(ctx context.Context, vendorID string) ([]model.VendorJob, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+vendorJobColumns+`
+		FROM vendor_jobs
+		WHERE vendor_id = $1
+		ORDER BY performed_on DESC, created_at DESC
+	`, vendorID)
+	if err != nil {
+		return nil, err
+	}
+	return scanVendorJobs(rows)
+}
+
+// GetJobsByPropertyID returns the jobs done at a property by any vendor,
+// newest first.
+func (s *VendorStore) GetJobsByPropertyID(ctx context.Context, propertyID string) ([]model.VendorJob, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+vendorJobColumns+`
+		FROM vendor_jobs
+		WHERE property_id = $1
+		ORDER BY performed_on DESC, created_at DESC
+	`, propertyID)
+	if err != nil {
+		return nil, err
+	}
+	return scanVendorJobs(rows)
+}
+
+func (s *VendorStore) GetJob(ctx context.Context, vendorID, id string) (model.VendorJob, error) {
+	j, err := scanVendorJob(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+vendorJobColumns+`
+		FROM vendor_jobs
+		WHERE id = $1 AND vendor_id = $2
+	`, id, vendorID))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.VendorJob{}, ErrNotFound
+	}
+	return j, err
+}
+
+func (s *VendorStore) CreateJob(ctx context.Context, j model.VendorJob) (model.VendorJob, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO vendor_jobs (`+vendorJobColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, j.ID, j.VendorID, j.PropertyID, j.MaintenanceRequestID, j.Description, j.PerformedOn, j.Hours, j.CreatedAt)
+
+	return j, err
+}
+
+func (s *VendorStore) DeleteJob(ctx context.Context, vendorID, id string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM vendor_jobs WHERE id = $1 AND vendor_id = $2
+	`, id, vendorID)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetInvoices returns a vendor's invoices, newest first.
+func (s *VendorStore) GetInvoices(ctx context.Context, vendorID string) ([]model.VendorInvoice, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT `+vendorInvoiceColumns+`
+		FROM vendor_invoices
+		WHERE vendor_id = $1
+		ORDER BY issued_on DESC, created_at DESC
+	`, vendorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invoices := []model.VendorInvoice{}
+	for rows.Next() {
+		i, err := scanVendorInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, i)
+	}
+
+	return invoices, rows.Err()
+}
+
+func (s *VendorStore) GetInvoice(ctx context.Context, vendorID, id string) (model.VendorInvoice, error) {
+	i, err := scanVendorInvoice(conn(ctx, s.db).QueryRow(ctx, `
+		SELECT `+vendorInvoiceColumns+`
+		FROM vendor_invoices
+		WHERE id = $1 AND vendor_id = $2
+	`, id, vendorID))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.VendorInvoice{}, ErrNotFound
+	}
+	return i, err
+}
+
+func (s *VendorStore) CreateInvoice(ctx context.Context, i model.VendorInvoice) (model.VendorInvoice, error) {
+	_, err := conn(ctx, s.db).Exec(ctx, `
+		INSERT INTO vendor_invoices (`+vendorInvoiceColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, i.ID, i.VendorID, i.JobID, i.Number, i.IssuedOn, i.DueOn, i.Amount, i.PaidOn, i.CreatedAt, i.UpdatedAt)
+
+	return i, err
+}
+
+func (s *VendorStore) UpdateInvoice(ctx context.Context, i model.VendorInvoice) (model.VendorInvoice, error) {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		UPDATE vendor_invoices
+		SET paid_on = $3, updated_at = $4
+		WHERE id = $1 AND vendor_id = $2
+	`, i.ID, i.VendorID, i.PaidOn, i.UpdatedAt)
+
+	if err != nil {
+		return model.VendorInvoice{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return model.VendorInvoice{}, ErrNotFound
+	}
+	return i, nil
+}
+
+func (s *VendorStore) DeleteInvoice(ctx context.Context, vendorID, id string) error {
+	result, err := conn(ctx, s.db).Exec(ctx, `
+		DELETE FROM vendor_invoices WHERE id = $1 AND vendor_id = $2
+	`, id, vendorID)
+
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanVendor(row pgx.Row) (model.Vendor, error) {
+	var v model.Vendor
+	err := row.Scan(&v.ID, &v.OrganizationID, &v.Name, &v.Trade, &v.ContactName, &v.Email, &v.Phone, &v.InsuranceExpiresOn, &v.HourlyRate,
+		&v.CreatedAt, &v.UpdatedAt)
+	return v, err
+}
+
+func scanVendorJobs(rows pgx.Rows) ([]model.VendorJob, error) {
+	defer rows.Close()
+
+	jobs := []model.VendorJob{}
+	for rows.Next() {
+		j, err := scanVendorJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+
+	return jobs, rows.Err()
+}
+
+func scanVendorJob(row pgx.Row) (model.VendorJob, error) {
+	var j model.VendorJob
+	err := row.Scan(&j.ID, &j.VendorID, &j.PropertyID, &j.MaintenanceRequestID, &j.Description, &j.PerformedOn, &j.Hours, &j.CreatedAt)
+	return j, err
+}
+
+func scanVendorInvoice(row pgx.Row) (model.VendorInvoice, error) {
+	var i model.VendorInvoice
+	err := row.Scan(&i.ID, &i.VendorID, &i.JobID, &i.Number, &i.IssuedOn, &i.DueOn, &i.Amount, &i.PaidOn, &i.CreatedAt, &i.UpdatedAt)
+	return i, err
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS payments (
+    id VARCHAR(64) PRIMARY KEY,
+    lease_id VARCHAR(64) NOT NULL REFERENCES leases(id) ON DELETE CASCADE,
+    amount DECIMAL(10,2) NOT NULL,
+    paid_at DATE NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_lease_id ON payments(lease_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS invoices (
+    id VARCHAR(64) PRIMARY KEY,
+    lease_id VARCHAR(64) NOT NULL REFERENCES leases(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    due_date DATE NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (lease_id, period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_due_date ON invoices(due_date);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS late_fee_policies (
+    id VARCHAR(64) PRIMARY KEY,
+    property_id VARCHAR(64) UNIQUE REFERENCES properties(id) ON DELETE CASCADE,
+    lease_id VARCHAR(64) UNIQUE REFERENCES leases(id) ON DELETE CASCADE,
+    grace_days INTEGER NOT NULL DEFAULT 0,
+    flat_fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+    percent DECIMAL(5,2) NOT NULL DEFAULT 0,
+    daily_fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+    max_fee DECIMAL(10,2),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((property_id IS NULL) <> (lease_id IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS lease_charges (
+    id VARCHAR(64) PRIMARY KEY,
+    lease_id VARCHAR(64) NOT NULL REFERENCES leases(id) ON DELETE CASCADE,
+    invoice_id VARCHAR(64) REFERENCES invoices(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    amount DECIMAL(10,2) NOT NULL,
+    charge_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (invoice_id, type, charge_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lease_charges_lease_id ON lease_charges(lease_id);
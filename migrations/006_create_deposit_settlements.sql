@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS deposit_settlements (
+    id VARCHAR(64) PRIMARY KEY,
+    lease_id VARCHAR(64) NOT NULL UNIQUE REFERENCES leases(id) ON DELETE CASCADE,
+    deposit_amount DECIMAL(10,2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    deadline DATE NOT NULL,
+    settled_at DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS deposit_deductions (
+    id VARCHAR(64) PRIMARY KEY,
+    settlement_id VARCHAR(64) NOT NULL REFERENCES deposit_settlements(id) ON DELETE CASCADE,
+    category VARCHAR(20) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    amount DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_deposit_deductions_settlement_id ON deposit_deductions(settlement_id);
@@ -0,0 +1,4 @@
+-- Occupancy is derived from the leases table; the stored flag is no longer read.
+ALTER TABLE properties DROP COLUMN IF EXISTS status;
+
+CREATE INDEX IF NOT EXISTS idx_leases_property_dates ON leases(property_id, start_date, end_date);
@@ -0,0 +1,3 @@
+ALTER TABLE leases ADD COLUMN IF NOT EXISTS previous_lease_id VARCHAR(64) REFERENCES leases(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leases_previous_lease_id ON leases(previous_lease_id);
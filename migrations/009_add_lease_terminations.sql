@@ -0,0 +1,5 @@
+ALTER TABLE leases ADD COLUMN IF NOT EXISTS notice_date DATE;
+ALTER TABLE leases ADD COLUMN IF NOT EXISTS move_out_date DATE;
+ALTER TABLE leases ADD COLUMN IF NOT EXISTS termination_reason VARCHAR(255);
+ALTER TABLE leases ADD COLUMN IF NOT EXISTS termination_fee DECIMAL(10,2);
+ALTER TABLE leases ADD COLUMN IF NOT EXISTS original_end_date DATE;
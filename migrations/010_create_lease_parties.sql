@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS lease_parties (
+    lease_id VARCHAR(64) NOT NULL REFERENCES leases(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(64) NOT NULL REFERENCES tenants(id),
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (lease_id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lease_parties_tenant_id ON lease_parties(tenant_id);
+
+-- Every lease's tenant_id is its primary party
+INSERT INTO lease_parties (lease_id, tenant_id, role, created_at)
+SELECT id, tenant_id, 'primary', created_at FROM leases
+ON CONFLICT (lease_id, tenant_id) DO NOTHING;
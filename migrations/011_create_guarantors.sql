@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS guarantors (
+    id VARCHAR(64) PRIMARY KEY,
+    first_name VARCHAR(100) NOT NULL,
+    last_name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    annual_income DECIMAL(12,2),
+    liability_cap DECIMAL(10,2),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS lease_guarantors (
+    lease_id VARCHAR(64) NOT NULL REFERENCES leases(id) ON DELETE CASCADE,
+    guarantor_id VARCHAR(64) NOT NULL REFERENCES guarantors(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (lease_id, guarantor_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lease_guarantors_guarantor_id ON lease_guarantors(guarantor_id);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS applications (
+    id VARCHAR(64) PRIMARY KEY,
+    property_id VARCHAR(64) NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    first_name VARCHAR(100) NOT NULL,
+    last_name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    employer VARCHAR(255) NOT NULL DEFAULT '',
+    job_title VARCHAR(255) NOT NULL DEFAULT '',
+    monthly_income DECIMAL(10,2) NOT NULL DEFAULT 0,
+    desired_start_date DATE NOT NULL,
+    desired_end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'submitted',
+    decision_reason VARCHAR(255) NOT NULL DEFAULT '',
+    decided_at TIMESTAMP,
+    tenant_id VARCHAR(64) REFERENCES tenants(id) ON DELETE SET NULL,
+    lease_id VARCHAR(64) REFERENCES leases(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_applications_property_id ON applications(property_id);
+CREATE INDEX IF NOT EXISTS idx_applications_status ON applications(status);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS buildings (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    address VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL DEFAULT '',
+    amenities TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- A property with a building is a unit of it; standalone properties keep a NULL building.
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS building_id VARCHAR(64) REFERENCES buildings(id) ON DELETE RESTRICT;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS unit_number VARCHAR(20);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_properties_building_unit ON properties(building_id, unit_number) WHERE building_id IS NOT NULL;
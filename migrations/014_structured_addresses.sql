@@ -0,0 +1,39 @@
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS street VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS unit VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS city VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS region VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS postal_code VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS country CHAR(2) NOT NULL DEFAULT '';
+
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS street VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS unit VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS city VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS region VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS postal_code VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS country CHAR(2) NOT NULL DEFAULT '';
+
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS street VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS unit VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS city VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS region VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS postal_code VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS country CHAR(2) NOT NULL DEFAULT '';
+
+-- Carry the old single-line addresses over as the street so no data is lost;
+-- the remaining parts have to be filled in by editing each record.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'properties' AND column_name = 'address') THEN
+        UPDATE properties SET street = address WHERE street = '';
+        ALTER TABLE properties DROP COLUMN address;
+    END IF;
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'buildings' AND column_name = 'address') THEN
+        UPDATE buildings SET street = address WHERE street = '';
+        ALTER TABLE buildings DROP COLUMN address;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_properties_city ON properties(LOWER(city));
+CREATE INDEX IF NOT EXISTS idx_properties_postal_code ON properties(postal_code);
+CREATE INDEX IF NOT EXISTS idx_tenants_city ON tenants(LOWER(city));
+CREATE INDEX IF NOT EXISTS idx_tenants_postal_code ON tenants(postal_code);
@@ -0,0 +1,9 @@
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS area DECIMAL(10,2);
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS bathrooms DECIMAL(3,1) NOT NULL DEFAULT 0;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS floor INTEGER;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS furnished BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS parking_spaces INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS pet_policy VARCHAR(20) NOT NULL DEFAULT 'not_allowed';
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS amenities TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_properties_amenities ON properties USING GIN (amenities);
@@ -0,0 +1,5 @@
+-- Listings page by keyset on (sort field, id); these cover the default sort.
+CREATE INDEX IF NOT EXISTS idx_properties_created_at_id ON properties(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_tenants_created_at_id ON tenants(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_leases_created_at_id ON leases(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_leases_status ON leases(status);
@@ -0,0 +1,19 @@
+-- Search uses the 'simple' configuration so names and street names are not
+-- stemmed, and queries match lexemes by prefix. Emails and phone numbers are
+-- indexed whole and split on punctuation, so partial input still matches.
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple',
+        first_name || ' ' || last_name || ' ' ||
+        email || ' ' || regexp_replace(email, '[^[:alnum:]]+', ' ', 'g') || ' ' ||
+        regexp_replace(COALESCE(phone, ''), '[^0-9]+', ' ', 'g') || ' ' ||
+        regexp_replace(COALESCE(phone, ''), '[^0-9]+', '', 'g'))
+) STORED;
+
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple',
+        street || ' ' || unit || ' ' || COALESCE(unit_number, '') || ' ' ||
+        city || ' ' || region || ' ' || postal_code || ' ' || replace(postal_code, ' ', ''))
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_tenants_search_vector ON tenants USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_properties_search_vector ON properties USING GIN (search_vector);
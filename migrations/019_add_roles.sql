@@ -0,0 +1,8 @@
+-- Accounts created before roles existed were all back-office staff.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'staff';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) REFERENCES tenants(id) ON DELETE SET NULL;
+
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS owner_user_id VARCHAR(64) REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_tenant_id ON users(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_properties_owner_user_id ON properties(owner_user_id);
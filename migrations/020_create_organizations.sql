@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Everything created before organizations existed belongs to the default
+-- organization, whose staff also administer the deployment.
+INSERT INTO organizations (id, name) VALUES ('default', 'Default') ON CONFLICT (id) DO NOTHING;
+
+-- The defaults only backfill existing rows; new rows must name their
+-- organization explicitly.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS organization_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS organization_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS organization_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS organization_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE leases ADD COLUMN IF NOT EXISTS organization_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE guarantors ADD COLUMN IF NOT EXISTS organization_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON DELETE RESTRICT;
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS organization_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON DELETE RESTRICT;
+
+ALTER TABLE users ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE properties ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE buildings ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE tenants ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE leases ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE guarantors ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE applications ALTER COLUMN organization_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_users_organization_id ON users(organization_id);
+CREATE INDEX IF NOT EXISTS idx_properties_organization_id ON properties(organization_id);
+CREATE INDEX IF NOT EXISTS idx_buildings_organization_id ON buildings(organization_id);
+CREATE INDEX IF NOT EXISTS idx_tenants_organization_id ON tenants(organization_id);
+CREATE INDEX IF NOT EXISTS idx_leases_organization_id ON leases(organization_id);
+CREATE INDEX IF NOT EXISTS idx_guarantors_organization_id ON guarantors(organization_id);
+CREATE INDEX IF NOT EXISTS idx_applications_organization_id ON applications(organization_id);
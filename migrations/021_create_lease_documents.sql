@@ -0,0 +1,11 @@
+-- Documents are stored elsewhere; a lease only keeps a link to each one.
+CREATE TABLE IF NOT EXISTS lease_documents (
+    id VARCHAR(64) PRIMARY KEY,
+    lease_id VARCHAR(64) NOT NULL REFERENCES leases(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    url TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lease_documents_lease_id ON lease_documents(lease_id);
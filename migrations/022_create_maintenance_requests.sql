@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS maintenance_requests (
+    id VARCHAR(64) PRIMARY KEY,
+    organization_id VARCHAR(64) NOT NULL REFERENCES organizations(id) ON DELETE RESTRICT,
+    property_id VARCHAR(64) NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    lease_id VARCHAR(64) REFERENCES leases(id) ON DELETE SET NULL,
+    tenant_id VARCHAR(64) REFERENCES tenants(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_requests_organization_id ON maintenance_requests(organization_id);
+CREATE INDEX IF NOT EXISTS idx_maintenance_requests_property_id ON maintenance_requests(property_id);
+CREATE INDEX IF NOT EXISTS idx_maintenance_requests_tenant_id ON maintenance_requests(tenant_id);
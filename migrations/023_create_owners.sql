@@ -0,0 +1,42 @@
+-- Owners are the third parties whose properties are managed on their
+-- behalf. user_id is the account an owner signs in with, if they have one.
+CREATE TABLE IF NOT EXISTS owners (
+    id VARCHAR(64) PRIMARY KEY,
+    organization_id VARCHAR(64) NOT NULL REFERENCES organizations(id) ON DELETE RESTRICT,
+    user_id VARCHAR(64) REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    street VARCHAR(255) NOT NULL DEFAULT '',
+    unit VARCHAR(50) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL DEFAULT '',
+    region VARCHAR(100) NOT NULL DEFAULT '',
+    postal_code VARCHAR(20) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL DEFAULT '',
+    management_fee_percent DECIMAL(5,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_owners_organization_id ON owners(organization_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_owners_user_id ON owners(user_id) WHERE user_id IS NOT NULL;
+
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS owner_id VARCHAR(64) REFERENCES owners(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_properties_owner_id ON properties(owner_id);
+
+-- Properties used to point straight at an owner's user account. Give each
+-- such account an owner record, without a management fee, and point the
+-- properties at that instead.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'properties' AND column_name = 'owner_user_id') THEN
+        INSERT INTO owners (id, organization_id, user_id, name, email)
+        SELECT 'owner-' || u.id, u.organization_id, u.id, u.name, u.email
+        FROM users u
+        WHERE u.id IN (SELECT owner_user_id FROM properties)
+        ON CONFLICT (id) DO NOTHING;
+
+        UPDATE properties SET owner_id = 'owner-' || owner_user_id WHERE owner_user_id IS NOT NULL;
+        ALTER TABLE properties DROP COLUMN owner_user_id;
+    END IF;
+END $$;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS property_expenses (
+    id VARCHAR(64) PRIMARY KEY,
+    property_id VARCHAR(64) NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    incurred_on DATE NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    amount DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_property_expenses_property_id ON property_expenses(property_id, incurred_on);
@@ -0,0 +1,36 @@
+-- Vendors are the contractors maintenance work is assigned to.
+CREATE TABLE IF NOT EXISTS vendors (
+    id VARCHAR(64) PRIMARY KEY,
+    organization_id VARCHAR(64) NOT NULL REFERENCES organizations(id) ON DELETE RESTRICT,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendors_organization_id ON vendors(organization_id);
+
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS priority VARCHAR(20) NOT NULL DEFAULT 'normal';
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS category VARCHAR(30) NOT NULL DEFAULT 'general';
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS vendor_id VARCHAR(64) REFERENCES vendors(id) ON DELETE SET NULL;
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS resolved_at TIMESTAMP;
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_requests_status ON maintenance_requests(status);
+CREATE INDEX IF NOT EXISTS idx_maintenance_requests_vendor_id ON maintenance_requests(vendor_id);
+
+CREATE TABLE IF NOT EXISTS maintenance_comments (
+    id VARCHAR(64) PRIMARY KEY,
+    request_id VARCHAR(64) NOT NULL REFERENCES maintenance_requests(id) ON DELETE CASCADE,
+    author_id VARCHAR(64) REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_comments_request_id ON maintenance_comments(request_id);
+
+-- The cost of a repair is recorded as a property expense, so it shows up
+-- in the owner's statement.
+ALTER TABLE property_expenses ADD COLUMN IF NOT EXISTS maintenance_request_id VARCHAR(64) REFERENCES maintenance_requests(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_property_expenses_maintenance_request_id ON property_expenses(maintenance_request_id);
@@ -0,0 +1,37 @@
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS trade VARCHAR(30) NOT NULL DEFAULT 'general';
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS contact_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS insurance_expires_on DATE;
+ALTER TABLE vendors ADD COLUMN IF NOT EXISTS hourly_rate DECIMAL(10,2);
+
+CREATE INDEX IF NOT EXISTS idx_vendors_trade ON vendors(trade);
+
+-- Jobs are the work a vendor has done at a property, optionally for a
+-- maintenance request.
+CREATE TABLE IF NOT EXISTS vendor_jobs (
+    id VARCHAR(64) PRIMARY KEY,
+    vendor_id VARCHAR(64) NOT NULL REFERENCES vendors(id) ON DELETE CASCADE,
+    property_id VARCHAR(64) NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    maintenance_request_id VARCHAR(64) REFERENCES maintenance_requests(id) ON DELETE SET NULL,
+    description TEXT NOT NULL,
+    performed_on DATE NOT NULL,
+    hours DECIMAL(6,2),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendor_jobs_vendor_id ON vendor_jobs(vendor_id);
+CREATE INDEX IF NOT EXISTS idx_vendor_jobs_property_id ON vendor_jobs(property_id);
+
+CREATE TABLE IF NOT EXISTS vendor_invoices (
+    id VARCHAR(64) PRIMARY KEY,
+    vendor_id VARCHAR(64) NOT NULL REFERENCES vendors(id) ON DELETE CASCADE,
+    job_id VARCHAR(64) REFERENCES vendor_jobs(id) ON DELETE SET NULL,
+    number VARCHAR(100) NOT NULL DEFAULT '',
+    issued_on DATE NOT NULL,
+    due_on DATE,
+    amount DECIMAL(10,2) NOT NULL,
+    paid_on DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendor_invoices_vendor_id ON vendor_invoices(vendor_id);
@@ -0,0 +1,18 @@
+-- A lease party with the role 'guarantor' is the same guarantee as a
+-- guarantor linked to the lease. Give each tenant who guarantees a lease a
+-- guarantor record and link it to those leases, so guarantors are listed
+-- and capped in one place whichever way they were added.
+INSERT INTO guarantors (id, organization_id, first_name, last_name, email, phone, address, created_at, updated_at)
+SELECT DISTINCT ON (t.id) 'guarantor-' || t.id, t.organization_id, t.first_name, t.last_name, t.email, COALESCE(t.phone, ''),
+    concat_ws(', ', NULLIF(t.street, ''), NULLIF(t.unit, ''), NULLIF(t.city, ''), NULLIF(t.region, ''), NULLIF(t.postal_code, ''), NULLIF(t.country, '')),
+    NOW(), NOW()
+FROM lease_parties lp
+JOIN tenants t ON t.id = lp.tenant_id
+WHERE lp.role = 'guarantor'
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO lease_guarantors (lease_id, guarantor_id, created_at)
+SELECT lp.lease_id, 'guarantor-' || lp.tenant_id, lp.created_at
+FROM lease_parties lp
+WHERE lp.role = 'guarantor'
+ON CONFLICT (lease_id, guarantor_id) DO NOTHING;
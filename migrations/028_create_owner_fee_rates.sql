@@ -0,0 +1,15 @@
+-- The management fee percents an owner has been charged over time. A rate
+-- applies from effective_from until the owner's next rate, so statements
+-- for past months keep the fee that was in effect then.
+CREATE TABLE IF NOT EXISTS owner_fee_rates (
+    owner_id VARCHAR(64) NOT NULL REFERENCES owners(id) ON DELETE CASCADE,
+    effective_from DATE NOT NULL,
+    fee_percent DECIMAL(5,2) NOT NULL,
+    PRIMARY KEY (owner_id, effective_from)
+);
+
+-- Owners created before rates were kept start out with their current fee.
+INSERT INTO owner_fee_rates (owner_id, effective_from, fee_percent)
+SELECT id, created_at::date, management_fee_percent
+FROM owners
+WHERE NOT EXISTS (SELECT 1 FROM owner_fee_rates r WHERE r.owner_id = owners.id);
@@ -0,0 +1,25 @@
+ALTER TABLE buildings ADD COLUMN IF NOT EXISTS owner_id VARCHAR(64) REFERENCES owners(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_buildings_owner_id ON buildings(owner_id);
+
+-- Buildings used to name their owner in free text. Give each distinct name
+-- within an organization an owner record, without a management fee, and
+-- point the buildings at that instead.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'buildings' AND column_name = 'owner') THEN
+        INSERT INTO owners (id, organization_id, name)
+        SELECT DISTINCT 'owner-' || md5(organization_id || ':' || btrim(owner)), organization_id, btrim(owner)
+        FROM buildings
+        WHERE btrim(owner) <> ''
+        ON CONFLICT (id) DO NOTHING;
+
+        INSERT INTO owner_fee_rates (owner_id, effective_from, fee_percent)
+        SELECT o.id, o.created_at::date, o.management_fee_percent
+        FROM owners o
+        WHERE NOT EXISTS (SELECT 1 FROM owner_fee_rates r WHERE r.owner_id = o.id);
+
+        UPDATE buildings SET owner_id = 'owner-' || md5(organization_id || ':' || btrim(owner))
+        WHERE btrim(owner) <> '';
+        ALTER TABLE buildings DROP COLUMN owner;
+    END IF;
+END $$;